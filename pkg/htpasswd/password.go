@@ -0,0 +1,26 @@
+package htpasswd
+
+import (
+	"fmt"
+
+	idputils "github.com/openshift-online/ocm-common/pkg/idp/utils"
+	passwordValidator "github.com/openshift-online/ocm-common/pkg/idp/validations"
+)
+
+// maxPasswordGenerationAttempts bounds retries when a generated password misses a character class
+const maxPasswordGenerationAttempts = 10
+
+// GenerateValidPassword generates a random password that passes the ocm-common PasswordValidator.
+// GenerateRandomPassword does not guarantee every character class is present, so retry until it does.
+func GenerateValidPassword() (string, error) {
+	for i := 0; i < maxPasswordGenerationAttempts; i++ {
+		password, err := idputils.GenerateRandomPassword()
+		if err != nil {
+			return "", fmt.Errorf("failed to generate password: %w", err)
+		}
+		if passwordValidator.PasswordValidator(password) == nil {
+			return password, nil
+		}
+	}
+	return "", fmt.Errorf("failed to generate a valid password after %d attempts", maxPasswordGenerationAttempts)
+}
@@ -0,0 +1,19 @@
+package htpasswd
+
+import (
+	"testing"
+
+	passwordValidator "github.com/openshift-online/ocm-common/pkg/idp/validations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateValidPassword(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		password, err := GenerateValidPassword()
+		assert.NoError(t, err)
+		assert.NoError(t, passwordValidator.PasswordValidator(password), "generated password %q should be valid", password)
+		assert.False(t, seen[password], "generated password %q should be unique", password)
+		seen[password] = true
+	}
+}
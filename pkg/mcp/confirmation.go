@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// confirmationTTL is how long a confirmation token for a destructive operation remains valid
+const confirmationTTL = 5 * time.Minute
+
+// pendingConfirmation records the operation a confirmation token was issued for
+type pendingConfirmation struct {
+	action     string
+	resourceID string
+	expiresAt  time.Time
+}
+
+// confirmationStore tracks short-lived, single-use tokens for two-phase destructive operations.
+// The first tool call issues a token bound to an action and resource; only a second call
+// presenting that token is allowed to perform the operation.
+type confirmationStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// newConfirmationStore creates a confirmation store with the given token lifetime
+func newConfirmationStore(ttl time.Duration) *confirmationStore {
+	return &confirmationStore{
+		pending: make(map[string]pendingConfirmation),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// Issue creates a new confirmation token for the given action and resource
+func (cs *confirmationStore) Issue(action, resourceID string) (string, time.Time, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	now := cs.now()
+	cs.purgeExpired(now)
+
+	expiresAt := now.Add(cs.ttl)
+	cs.pending[token] = pendingConfirmation{
+		action:     action,
+		resourceID: resourceID,
+		expiresAt:  expiresAt,
+	}
+	return token, expiresAt, nil
+}
+
+// Consume validates a confirmation token for the given action and resource.
+// Tokens are single-use: a token is invalidated on first presentation, even if it does not match.
+func (cs *confirmationStore) Consume(token, action, resourceID string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	pending, exists := cs.pending[token]
+	if !exists {
+		return fmt.Errorf("unknown or already used confirmation token")
+	}
+	delete(cs.pending, token)
+
+	if cs.now().After(pending.expiresAt) {
+		return fmt.Errorf("confirmation token expired at %s", pending.expiresAt.Format(time.RFC3339))
+	}
+	if pending.action != action || pending.resourceID != resourceID {
+		return fmt.Errorf("confirmation token was not issued for %s on '%s'", action, resourceID)
+	}
+	return nil
+}
+
+// purgeExpired removes expired tokens; callers must hold cs.mu
+func (cs *confirmationStore) purgeExpired(now time.Time) {
+	for token, pending := range cs.pending {
+		if now.After(pending.expiresAt) {
+			delete(cs.pending, token)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmationStore(t *testing.T) {
+	t.Run("valid token is accepted once", func(t *testing.T) {
+		cs := newConfirmationStore(time.Minute)
+		token, _, err := cs.Issue("delete_cluster", "cluster-1")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+
+		assert.NoError(t, cs.Consume(token, "delete_cluster", "cluster-1"))
+		assert.Error(t, cs.Consume(token, "delete_cluster", "cluster-1"))
+	})
+
+	t.Run("unknown token is rejected", func(t *testing.T) {
+		cs := newConfirmationStore(time.Minute)
+		assert.Error(t, cs.Consume("does-not-exist", "delete_cluster", "cluster-1"))
+	})
+
+	t.Run("token bound to a different resource is rejected and invalidated", func(t *testing.T) {
+		cs := newConfirmationStore(time.Minute)
+		token, _, err := cs.Issue("delete_cluster", "cluster-1")
+		assert.NoError(t, err)
+
+		assert.Error(t, cs.Consume(token, "delete_cluster", "cluster-2"))
+		assert.Error(t, cs.Consume(token, "delete_cluster", "cluster-1"))
+	})
+
+	t.Run("token bound to a different action is rejected", func(t *testing.T) {
+		cs := newConfirmationStore(time.Minute)
+		token, _, err := cs.Issue("delete_cluster", "cluster-1")
+		assert.NoError(t, err)
+
+		assert.Error(t, cs.Consume(token, "delete_node_pool", "cluster-1"))
+	})
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		cs := newConfirmationStore(time.Minute)
+		now := time.Now()
+		cs.now = func() time.Time { return now }
+
+		token, expiresAt, err := cs.Issue("delete_cluster", "cluster-1")
+		assert.NoError(t, err)
+		assert.Equal(t, now.Add(time.Minute), expiresAt)
+
+		cs.now = func() time.Time { return now.Add(2 * time.Minute) }
+		assert.Error(t, cs.Consume(token, "delete_cluster", "cluster-1"))
+	})
+}
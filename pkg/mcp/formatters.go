@@ -1,12 +1,19 @@
 package mcp
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	accountsmgmt "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	clustersmgmt "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/tiwillia/rosa-mcp-go/pkg/ocm"
+	"github.com/tiwillia/rosa-mcp-go/pkg/validation"
 )
 
 // formatAccountResponse formats account information for display
@@ -48,44 +55,275 @@ func formatAccountResponse(account *accountsmgmt.Account) string {
 	return strings.Join(parts, "\n")
 }
 
-// formatClustersResponse formats cluster list for display  
-func formatClustersResponse(clusters []*clustersmgmt.Cluster) string {
+// validClusterFields lists the fields get_clusters can show for each cluster
+var validClusterFields = []string{"name", "id", "state", "api_url", "console_url", "version", "region", "product", "hypershift", "creator", "created"}
+
+// defaultClusterFields are the fields get_clusters shows when none are selected
+var defaultClusterFields = []string{"name", "id", "state", "api_url", "console_url", "version", "region"}
+
+// formatClustersResponse formats a list of clusters for display, showing the selected fields in a fixed order
+func formatClustersResponse(clusters []*clustersmgmt.Cluster, fields []string) string {
 	if len(clusters) == 0 {
 		return "No clusters found"
 	}
 
 	var parts []string
 	parts = append(parts, fmt.Sprintf("=== Clusters (%d found) ===", len(clusters)))
-	
+
 	for i, cluster := range clusters {
 		if i > 0 {
 			parts = append(parts, "---")
 		}
-		
-		parts = append(parts, fmt.Sprintf("Name: %s", cluster.Name()))
-		parts = append(parts, fmt.Sprintf("ID: %s", cluster.ID()))
-		parts = append(parts, fmt.Sprintf("State: %s", cluster.State()))
-		
+
+		for _, field := range validClusterFields {
+			if !slices.Contains(fields, field) {
+				continue
+			}
+			if line := clusterField(cluster, field); line != "" {
+				parts = append(parts, line)
+			}
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// clusterField returns the line showing one field of a cluster, or "" when the cluster has no value for it
+func clusterField(cluster *clustersmgmt.Cluster, field string) string {
+	switch field {
+	case "name":
+		return fmt.Sprintf("Name: %s", cluster.Name())
+	case "id":
+		return fmt.Sprintf("ID: %s", cluster.ID())
+	case "state":
+		return fmt.Sprintf("State: %s", cluster.State())
+	case "api_url":
 		if api := cluster.API(); api != nil && api.URL() != "" {
-			parts = append(parts, fmt.Sprintf("API URL: %s", api.URL()))
+			return fmt.Sprintf("API URL: %s", api.URL())
 		}
-		
+	case "console_url":
 		if console := cluster.Console(); console != nil && console.URL() != "" {
-			parts = append(parts, fmt.Sprintf("Console URL: %s", console.URL()))
+			return fmt.Sprintf("Console URL: %s", console.URL())
 		}
-		
+	case "version":
 		if version := cluster.Version(); version != nil && version.ID() != "" {
-			parts = append(parts, fmt.Sprintf("Version: %s", version.ID()))
+			return fmt.Sprintf("Version: %s", version.ID())
 		}
-		
+	case "region":
 		if region := cluster.Region(); region != nil && region.ID() != "" {
-			parts = append(parts, fmt.Sprintf("Region: %s", region.ID()))
+			return fmt.Sprintf("Region: %s", region.ID())
+		}
+	case "product":
+		if product := cluster.Product(); product != nil && product.ID() != "" {
+			return fmt.Sprintf("Product: %s", product.ID())
+		}
+	case "hypershift":
+		return fmt.Sprintf("Hosted Control Plane: %t", cluster.Hypershift().Enabled())
+	case "creator":
+		if creator := cluster.Properties()[ocm.CreatorARNProperty]; creator != "" {
+			return fmt.Sprintf("Creator: %s", creator)
+		}
+	case "created":
+		if !cluster.CreationTimestamp().IsZero() {
+			return fmt.Sprintf("Created: %s", cluster.CreationTimestamp().Format(time.RFC3339))
 		}
 	}
-	
+	return ""
+}
+
+// clusterAccessDetails returns the lines describing how a cluster's API is reached and which proxy it uses
+func clusterAccessDetails(cluster *clustersmgmt.Cluster) []string {
+	var parts []string
+
+	private := cluster.API() != nil && cluster.API().Listening() == clustersmgmt.ListeningMethodInternal
+	if aws := cluster.AWS(); aws != nil && aws.PrivateLink() {
+		private = true
+	}
+	if private {
+		parts = append(parts, "API Visibility: Private (AWS PrivateLink; reachable only from inside the VPC or connected networks)")
+	} else {
+		parts = append(parts, "API Visibility: Public (reachable from the internet)")
+	}
+
+	if proxy := cluster.Proxy(); proxy != nil {
+		if httpProxy := proxy.HTTPProxy(); httpProxy != "" {
+			parts = append(parts, fmt.Sprintf("HTTP Proxy: %s", ocm.RedactProxyURL(httpProxy)))
+		}
+		if httpsProxy := proxy.HTTPSProxy(); httpsProxy != "" {
+			parts = append(parts, fmt.Sprintf("HTTPS Proxy: %s", ocm.RedactProxyURL(httpsProxy)))
+		}
+		if noProxy := proxy.NoProxy(); noProxy != "" {
+			parts = append(parts, fmt.Sprintf("No Proxy: %s", noProxy))
+		}
+	}
+	if cluster.AdditionalTrustBundle() != "" {
+		parts = append(parts, "Additional Trust Bundle: configured")
+	}
+
+	return parts
+}
+
+// formatClusterConfigValidation formats the outcome of validating a cluster create spec locally
+func formatClusterConfigValidation(spec *ocm.ClusterCreateSpec, err error) string {
+	var parts []string
+	parts = append(parts, "=== Cluster Configuration Validation ===")
+	parts = append(parts, fmt.Sprintf("Cluster: %s", spec.Name))
+	parts = append(parts, fmt.Sprintf("Region: %s", spec.Region))
+
+	var issues validation.Issues
+	switch {
+	case err == nil:
+		parts = append(parts, "✓ No problems found")
+	case errors.As(err, &issues):
+		parts = append(parts, fmt.Sprintf("✗ %d problem(s) found:", len(issues)))
+		for _, issue := range issues {
+			parts = append(parts, fmt.Sprintf("  - %s", issue.Error()))
+		}
+	default:
+		parts = append(parts, "✗ 1 problem(s) found:")
+		parts = append(parts, fmt.Sprintf("  - %v", err))
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "Note: These checks run locally. Use create_rosa_hcp_cluster with dry_run to also validate the subnets and the cluster with OCM.")
+	return strings.Join(parts, "\n")
+}
+
+// formatClusterDryRunResponse formats the outcome of a cluster creation dry run with the submitted cluster spec
+func formatClusterDryRunResponse(result *ocm.ClusterDryRunResult) string {
+	var parts []string
+	parts = append(parts, "=== Cluster Creation Dry Run ===")
+
+	if result.Passed() {
+		parts = append(parts, "✓ Validation passed: OCM would accept this cluster")
+	} else {
+		parts = append(parts, "✗ Validation failed")
+		parts = append(parts, fmt.Sprintf("Reason: %v", result.ValidationError))
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "--- Submitted Cluster Spec ---")
+	parts = append(parts, "Defaults that OCM applies when it creates the cluster are not shown.")
+	var payload bytes.Buffer
+	display, err := redactClusterProxy(result.Cluster)
+	if err == nil {
+		err = clustersmgmt.MarshalCluster(display, &payload)
+	}
+	if err != nil {
+		parts = append(parts, fmt.Sprintf("Unable to render the cluster spec: %v", err))
+	} else {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, payload.Bytes(), "", "  "); err != nil {
+			parts = append(parts, payload.String())
+		} else {
+			parts = append(parts, indented.String())
+		}
+	}
+
+	if result.Cluster.Network() == nil {
+		parts = append(parts, fmt.Sprintf("Network: OCM defaults (machine %s, service %s, pod %s, host prefix /%d)",
+			ocm.DefaultMachineCIDR, ocm.DefaultServiceCIDR, ocm.DefaultPodCIDR, ocm.DefaultHostPrefix))
+	}
+	if result.Cluster.Version() == nil {
+		parts = append(parts, "Version: OCM default version")
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "Note: This was a dry run; no cluster was created.")
+	if result.Passed() {
+		parts = append(parts, "Note: Call create_rosa_hcp_cluster again without dry_run to create the cluster.")
+	}
 	return strings.Join(parts, "\n")
 }
 
+// redactClusterProxy returns a copy of cluster whose proxy URLs have their credentials removed
+func redactClusterProxy(cluster *clustersmgmt.Cluster) (*clustersmgmt.Cluster, error) {
+	proxy := cluster.Proxy()
+	if proxy == nil {
+		return cluster, nil
+	}
+	redacted := clustersmgmt.NewProxy().Copy(proxy)
+	if httpProxy := proxy.HTTPProxy(); httpProxy != "" {
+		redacted = redacted.HTTPProxy(ocm.RedactProxyURL(httpProxy))
+	}
+	if httpsProxy := proxy.HTTPSProxy(); httpsProxy != "" {
+		redacted = redacted.HTTPSProxy(ocm.RedactProxyURL(httpsProxy))
+	}
+	return clustersmgmt.NewCluster().Copy(cluster).Proxy(redacted).Build()
+}
+
+// formatKeyValues formats a map as comma-separated key=value pairs sorted by key
+func formatKeyValues(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, values[key]))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// clusterNetworkDetails returns the lines describing a cluster's network plugin and CIDRs
+func clusterNetworkDetails(cluster *clustersmgmt.Cluster) []string {
+	network := cluster.Network()
+	if network == nil || network.Empty() {
+		return nil
+	}
+
+	parts := []string{"--- Network ---"}
+	if network.Type() != "" {
+		parts = append(parts, fmt.Sprintf("Network Type: %s", network.Type()))
+	}
+	if network.MachineCIDR() != "" {
+		parts = append(parts, fmt.Sprintf("Machine CIDR: %s", network.MachineCIDR()))
+	}
+	if network.ServiceCIDR() != "" {
+		parts = append(parts, fmt.Sprintf("Service CIDR: %s", network.ServiceCIDR()))
+	}
+	if network.PodCIDR() != "" {
+		parts = append(parts, fmt.Sprintf("Pod CIDR: %s", network.PodCIDR()))
+	}
+	if hostPrefix, ok := network.GetHostPrefix(); ok {
+		parts = append(parts, fmt.Sprintf("Host Prefix: /%d", hostPrefix))
+	}
+	return parts
+}
+
+// clusterEncryptionDetails returns the lines describing how a cluster's volumes and etcd are encrypted
+func clusterEncryptionDetails(cluster *clustersmgmt.Cluster) []string {
+	var parts []string
+	parts = append(parts, "--- Encryption ---")
+
+	kmsKeyARN := ""
+	etcdKeyARN := ""
+	if aws := cluster.AWS(); aws != nil {
+		kmsKeyARN = aws.KMSKeyArn()
+		if etcd := aws.EtcdEncryption(); etcd != nil {
+			etcdKeyARN = etcd.KMSKeyARN()
+		}
+	}
+
+	if kmsKeyARN != "" {
+		parts = append(parts, fmt.Sprintf("EBS Volumes: customer-managed KMS key %s", kmsKeyARN))
+	} else {
+		parts = append(parts, "EBS Volumes: AWS managed key")
+	}
+
+	switch {
+	case etcdKeyARN != "":
+		parts = append(parts, fmt.Sprintf("etcd Encryption: enabled with customer-managed KMS key %s", etcdKeyARN))
+	case cluster.EtcdEncryption():
+		parts = append(parts, "etcd Encryption: enabled")
+	default:
+		parts = append(parts, "etcd Encryption: disabled")
+	}
+
+	return parts
+}
+
 // formatClusterResponse formats single cluster details for display
 func formatClusterResponse(cluster *clustersmgmt.Cluster) string {
 	if cluster == nil {
@@ -115,6 +353,19 @@ func formatClusterResponse(cluster *clustersmgmt.Cluster) string {
 		parts = append(parts, fmt.Sprintf("Region: %s", region.ID()))
 	}
 	
+	parts = append(parts, clusterAccessDetails(cluster)...)
+	parts = append(parts, fmt.Sprintf("Multi-AZ: %t", cluster.MultiAZ()))
+	if nodes := cluster.Nodes(); nodes != nil {
+		if machineType := nodes.ComputeMachineType(); machineType != nil && machineType.ID() != "" {
+			parts = append(parts, fmt.Sprintf("Compute Machine Type: %s", machineType.ID()))
+		}
+		if autoscale := nodes.AutoscaleCompute(); autoscale != nil {
+			parts = append(parts, fmt.Sprintf("Compute Autoscaling: %d-%d nodes", autoscale.MinReplicas(), autoscale.MaxReplicas()))
+		} else if compute, ok := nodes.GetCompute(); ok {
+			parts = append(parts, fmt.Sprintf("Compute Nodes: %d", compute))
+		}
+	}
+
 	if aws := cluster.AWS(); aws != nil {
 		parts = append(parts, "--- AWS Configuration ---")
 		if accountID := aws.AccountID(); accountID != "" {
@@ -134,7 +385,17 @@ func formatClusterResponse(cluster *clustersmgmt.Cluster) string {
 		if len(aws.SubnetIDs()) > 0 {
 			parts = append(parts, fmt.Sprintf("Subnet IDs: %s", strings.Join(aws.SubnetIDs(), ", ")))
 		}
+		if tags := aws.Tags(); len(tags) > 0 {
+			parts = append(parts, fmt.Sprintf("AWS Tags: %s", formatKeyValues(tags)))
+		}
+	}
+
+	if properties := cluster.Properties(); len(properties) > 0 {
+		parts = append(parts, fmt.Sprintf("Properties: %s", formatKeyValues(properties)))
 	}
+
+	parts = append(parts, clusterNetworkDetails(cluster)...)
+	parts = append(parts, clusterEncryptionDetails(cluster)...)
 	
 	if hypershift := cluster.Hypershift(); hypershift != nil {
 		parts = append(parts, fmt.Sprintf("Hypershift Enabled: %t", hypershift.Enabled()))
@@ -173,12 +434,14 @@ func formatClusterCreateResponse(cluster *clustersmgmt.Cluster) string {
 		parts = append(parts, fmt.Sprintf("Region: %s", region.ID()))
 	}
 	
+	parts = append(parts, clusterAccessDetails(cluster)...)
+
 	if creationTime := cluster.CreationTimestamp(); !creationTime.IsZero() {
 		parts = append(parts, fmt.Sprintf("Creation Started: %s", creationTime.Format(time.RFC3339)))
 	}
 	
 	parts = append(parts, "")
-	parts = append(parts, "Note: Cluster provisioning is in progress. Use 'get_cluster' to check status.")
+	parts = append(parts, "Note: Cluster provisioning is in progress. Use 'wait_for_cluster_state' to wait until the cluster is ready instead of polling 'get_cluster'.")
 	
 	if api := cluster.API(); api != nil && api.URL() != "" {
 		parts = append(parts, fmt.Sprintf("API URL will be: %s", api.URL()))
@@ -191,22 +454,1061 @@ func formatClusterCreateResponse(cluster *clustersmgmt.Cluster) string {
 	return strings.Join(parts, "\n")
 }
 
-// FormatHTPasswdIdentityProviderResult - Enhanced with ROSA CLI patterns
-func FormatHTPasswdIdentityProviderResult(
-	idp *clustersmgmt.IdentityProvider,
-	cluster *clustersmgmt.Cluster,
-	userCount int,
-) string {
-	var output strings.Builder
+// formatClusterDeleteConfirmation formats the summary shown before a cluster is deleted
+func formatClusterDeleteConfirmation(cluster *clustersmgmt.Cluster, token string, expiresAt time.Time) string {
+	var parts []string
+	parts = append(parts, "=== Cluster Deletion Requested ===")
+	parts = append(parts, "⚠ The following cluster will be permanently deleted:")
+	parts = append(parts, fmt.Sprintf("Name: %s", cluster.Name()))
+	parts = append(parts, fmt.Sprintf("ID: %s", cluster.ID()))
+	parts = append(parts, fmt.Sprintf("State: %s", cluster.State()))
 
-	output.WriteString("HTPasswd Identity Provider Setup Complete\n\n")
+	if version := cluster.Version(); version != nil && version.ID() != "" {
+		parts = append(parts, fmt.Sprintf("Version: %s", version.ID()))
+	}
 
-	// Provider Details (similar to ROSA CLI output format)
-	output.WriteString("Provider Details:\n")
-	output.WriteString(fmt.Sprintf("- Name: %s\n", idp.Name()))
-	output.WriteString("- Type: HTPasswd\n")
-	output.WriteString(fmt.Sprintf("- Mapping Method: %s\n", idp.MappingMethod()))
-	output.WriteString(fmt.Sprintf("- Users Created: %d\n", userCount))
+	if region := cluster.Region(); region != nil && region.ID() != "" {
+		parts = append(parts, fmt.Sprintf("Region: %s", region.ID()))
+	}
+
+	if aws := cluster.AWS(); aws != nil && aws.AccountID() != "" {
+		parts = append(parts, fmt.Sprintf("AWS Account ID: %s", aws.AccountID()))
+	}
+
+	if api := cluster.API(); api != nil && api.URL() != "" {
+		parts = append(parts, fmt.Sprintf("API URL: %s", api.URL()))
+	}
+
+	if creationTime := cluster.CreationTimestamp(); !creationTime.IsZero() {
+		parts = append(parts, fmt.Sprintf("Created: %s", creationTime.Format(time.RFC3339)))
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "All workloads, node pools and data on this cluster will be destroyed.")
+	parts = append(parts, fmt.Sprintf("Confirmation Token: %s", token))
+	parts = append(parts, fmt.Sprintf("Token Expires: %s", expiresAt.Format(time.RFC3339)))
+	parts = append(parts, "")
+	parts = append(parts, "Note: Nothing has been deleted yet. Only if the user explicitly confirms, call 'delete_cluster' again with this cluster_id and confirmation_token.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatClusterDeleteResponse formats cluster deletion response for display
+func formatClusterDeleteResponse(cluster *clustersmgmt.Cluster) string {
+	var parts []string
+	parts = append(parts, "=== Cluster Deletion Response ===")
+	parts = append(parts, "✓ Cluster deletion initiated")
+	parts = append(parts, fmt.Sprintf("Name: %s", cluster.Name()))
+	parts = append(parts, fmt.Sprintf("ID: %s", cluster.ID()))
+	parts = append(parts, "")
+	parts = append(parts, "Note: Cluster uninstallation is in progress. Use 'get_cluster' to check status.")
+
+	return strings.Join(parts, "\n")
+}
+
+// nodePoolDetails returns the display lines describing a node pool
+func nodePoolDetails(nodePool *clustersmgmt.NodePool) []string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("ID: %s", nodePool.ID()))
+
+	if awsNodePool := nodePool.AWSNodePool(); awsNodePool != nil && awsNodePool.InstanceType() != "" {
+		parts = append(parts, fmt.Sprintf("Instance Type: %s", awsNodePool.InstanceType()))
+	}
+
+	if autoscaling, ok := nodePool.GetAutoscaling(); ok {
+		parts = append(parts, fmt.Sprintf("Autoscaling: %d-%d replicas", autoscaling.MinReplica(), autoscaling.MaxReplica()))
+	} else {
+		parts = append(parts, fmt.Sprintf("Replicas: %d", nodePool.Replicas()))
+	}
+
+	if status := nodePool.Status(); status != nil {
+		parts = append(parts, fmt.Sprintf("Current Replicas: %d", status.CurrentReplicas()))
+		if state := status.State(); state != nil && state.NodePoolStateValue() != "" {
+			parts = append(parts, fmt.Sprintf("State: %s", state.NodePoolStateValue()))
+		}
+		if message := status.Message(); message != "" {
+			parts = append(parts, fmt.Sprintf("Status Message: %s", message))
+		}
+	}
+
+	if version := nodePool.Version(); version != nil && version.ID() != "" {
+		parts = append(parts, fmt.Sprintf("Version: %s", version.RawID()))
+	}
+
+	if subnet := nodePool.Subnet(); subnet != "" {
+		parts = append(parts, fmt.Sprintf("Subnet: %s", subnet))
+	}
+
+	if az := nodePool.AvailabilityZone(); az != "" {
+		parts = append(parts, fmt.Sprintf("Availability Zone: %s", az))
+	}
+
+	parts = append(parts, fmt.Sprintf("Auto Repair: %t", nodePool.AutoRepair()))
+
+	if labels := nodePool.Labels(); len(labels) > 0 {
+		parts = append(parts, fmt.Sprintf("Labels: %s", formatKeyValues(labels)))
+	}
+
+	if taints := nodePool.Taints(); len(taints) > 0 {
+		values := make([]string, 0, len(taints))
+		for _, taint := range taints {
+			values = append(values, fmt.Sprintf("%s=%s:%s", taint.Key(), taint.Value(), taint.Effect()))
+		}
+		parts = append(parts, fmt.Sprintf("Taints: %s", strings.Join(values, ", ")))
+	}
+
+	return parts
+}
+
+// formatNodePoolsResponse formats a cluster's node pool list for display
+func formatNodePoolsResponse(clusterID string, nodePools []*clustersmgmt.NodePool) string {
+	if len(nodePools) == 0 {
+		return fmt.Sprintf("No node pools found for cluster %s", clusterID)
+	}
+
+	var parts []string
+	parts = append(parts, fmt.Sprintf("=== Node Pools for cluster %s (%d found) ===", clusterID, len(nodePools)))
+
+	for i, nodePool := range nodePools {
+		if i > 0 {
+			parts = append(parts, "---")
+		}
+		parts = append(parts, nodePoolDetails(nodePool)...)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// formatNodePoolResponse formats a single node pool after a create or update for display
+func formatNodePoolResponse(title string, clusterID string, nodePool *clustersmgmt.NodePool) string {
+	if nodePool == nil {
+		return "No node pool information available"
+	}
+
+	var parts []string
+	parts = append(parts, fmt.Sprintf("=== %s ===", title))
+	parts = append(parts, fmt.Sprintf("Cluster: %s", clusterID))
+	parts = append(parts, nodePoolDetails(nodePool)...)
+	parts = append(parts, "")
+	parts = append(parts, "Note: Node changes are applied asynchronously. Use 'list_node_pools' to check status.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatNodePoolDeleteConfirmation formats the summary shown before a node pool is deleted
+func formatNodePoolDeleteConfirmation(clusterID string, nodePool *clustersmgmt.NodePool, token string, expiresAt time.Time) string {
+	var parts []string
+	parts = append(parts, "=== Node Pool Deletion Requested ===")
+	parts = append(parts, "⚠ The following node pool will be deleted and its nodes drained and terminated:")
+	parts = append(parts, fmt.Sprintf("Cluster: %s", clusterID))
+	parts = append(parts, nodePoolDetails(nodePool)...)
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("Confirmation Token: %s", token))
+	parts = append(parts, fmt.Sprintf("Token Expires: %s", expiresAt.Format(time.RFC3339)))
+	parts = append(parts, "")
+	parts = append(parts, "Note: Nothing has been deleted yet. Only if the user explicitly confirms, call 'delete_node_pool' again with this confirmation_token.")
+
+	return strings.Join(parts, "\n")
+}
+
+// upgradePolicy is implemented by both control plane and node pool upgrade policies
+type upgradePolicy interface {
+	ID() string
+	Version() string
+	Schedule() string
+	ScheduleType() clustersmgmt.ScheduleType
+	NextRun() time.Time
+	State() *clustersmgmt.UpgradePolicyState
+}
+
+// upgradePolicySection groups the upgrade policies of the control plane or one node pool
+type upgradePolicySection struct {
+	title    string
+	policies []upgradePolicy
+}
+
+// upgradePolicyDetails returns the display lines describing an upgrade policy
+func upgradePolicyDetails(policy upgradePolicy) []string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("Policy ID: %s", policy.ID()))
+	parts = append(parts, fmt.Sprintf("Schedule Type: %s", policy.ScheduleType()))
+
+	if version := policy.Version(); version != "" {
+		parts = append(parts, fmt.Sprintf("Version: %s", version))
+	}
+
+	if schedule := policy.Schedule(); schedule != "" {
+		parts = append(parts, fmt.Sprintf("Schedule: %s", schedule))
+	}
+
+	if nextRun := policy.NextRun(); !nextRun.IsZero() {
+		parts = append(parts, fmt.Sprintf("Next Run: %s", nextRun.Format(time.RFC3339)))
+	}
+
+	if state := policy.State(); state != nil && state.Value() != "" {
+		parts = append(parts, fmt.Sprintf("State: %s", state.Value()))
+		if description := state.Description(); description != "" {
+			parts = append(parts, fmt.Sprintf("State Description: %s", description))
+		}
+	}
+
+	return parts
+}
+
+// formatUpgradeVersionsResponse formats the available upgrades of a cluster and its node pools for display
+func formatUpgradeVersionsResponse(cluster *clustersmgmt.Cluster, nodePools []*clustersmgmt.NodePool) string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("=== Available Upgrades for %s (%s) ===", cluster.Name(), cluster.ID()))
+
+	parts = append(parts, "--- Control Plane ---")
+	parts = append(parts, fmt.Sprintf("Current Version: %s", cluster.Version().RawID()))
+	if upgrades := cluster.Version().AvailableUpgrades(); len(upgrades) > 0 {
+		parts = append(parts, fmt.Sprintf("Available Upgrades: %s", strings.Join(upgrades, ", ")))
+	} else {
+		parts = append(parts, "Available Upgrades: none (already on the latest available version)")
+	}
+
+	for _, nodePool := range nodePools {
+		parts = append(parts, fmt.Sprintf("--- Node Pool %s ---", nodePool.ID()))
+		parts = append(parts, fmt.Sprintf("Current Version: %s", nodePool.Version().RawID()))
+		if upgrades := ocm.NodePoolAvailableUpgrades(cluster, nodePool); len(upgrades) > 0 {
+			parts = append(parts, fmt.Sprintf("Available Upgrades: %s", strings.Join(upgrades, ", ")))
+		} else {
+			parts = append(parts, "Available Upgrades: none (node pools cannot be upgraded past the control plane version)")
+		}
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "Note: Upgrade the control plane before its node pools. Use 'schedule_upgrade' to schedule an upgrade.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatVersionsResponse formats the installable OpenShift versions of a channel group for display
+func formatVersionsResponse(channelGroup string, versions []*clustersmgmt.Version, now time.Time) string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("=== ROSA HCP Versions (channel group: %s) ===", channelGroup))
+
+	if len(versions) == 0 {
+		parts = append(parts, "No versions available")
+		return strings.Join(parts, "\n")
+	}
+
+	for _, version := range versions {
+		if version.Default() {
+			parts = append(parts, fmt.Sprintf("Default Version: %s", version.RawID()))
+			break
+		}
+	}
+	parts = append(parts, fmt.Sprintf("Total: %d", len(versions)))
+	parts = append(parts, "")
+
+	for _, version := range versions {
+		line := version.RawID()
+		if version.Default() {
+			line += " (default)"
+		}
+		if eol, ok := version.GetEndOfLifeTimestamp(); ok && !eol.IsZero() {
+			if eol.Before(now) {
+				line += fmt.Sprintf(" - END OF LIFE since %s", eol.Format("2006-01-02"))
+			} else {
+				line += fmt.Sprintf(" - end of life %s", eol.Format("2006-01-02"))
+			}
+		}
+		parts = append(parts, line)
+	}
+
+	parts = append(parts, "")
+	if channelGroup == ocm.DefaultChannelGroup {
+		parts = append(parts, "Note: Pass one of these versions as the version parameter of 'create_rosa_hcp_cluster'; the default version is used when it is omitted.")
+	} else {
+		parts = append(parts, fmt.Sprintf("Note: Pass one of these versions as the version parameter of 'create_rosa_hcp_cluster' together with channel_group=%s.", channelGroup))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// formatRegionsResponse formats the available AWS regions for display
+func formatRegionsResponse(regions []*clustersmgmt.CloudRegion, hcpOnly bool) string {
+	var parts []string
+	if hcpOnly {
+		parts = append(parts, "=== AWS Regions Supporting ROSA HCP ===")
+	} else {
+		parts = append(parts, "=== AWS Regions ===")
+	}
+
+	if len(regions) == 0 {
+		parts = append(parts, "No regions available")
+		return strings.Join(parts, "\n")
+	}
+	parts = append(parts, fmt.Sprintf("Total: %d", len(regions)))
+	parts = append(parts, "")
+
+	for _, region := range regions {
+		line := region.ID()
+		if region.DisplayName() != "" {
+			line += fmt.Sprintf(" - %s", region.DisplayName())
+		}
+		var notes []string
+		if !hcpOnly && region.SupportsHypershift() {
+			notes = append(notes, "HCP")
+		}
+		if region.SupportsMultiAZ() {
+			notes = append(notes, "multi-AZ")
+		}
+		if region.GovCloud() {
+			notes = append(notes, "GovCloud")
+		}
+		if len(notes) > 0 {
+			line += fmt.Sprintf(" [%s]", strings.Join(notes, ", "))
+		}
+		parts = append(parts, line)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// formatMachineTypesResponse formats the available AWS machine types for display
+func formatMachineTypesResponse(machineTypes []*clustersmgmt.MachineType) string {
+	var parts []string
+	parts = append(parts, "=== AWS Machine Types ===")
+
+	if len(machineTypes) == 0 {
+		parts = append(parts, "No machine types match the filters")
+		return strings.Join(parts, "\n")
+	}
+	parts = append(parts, fmt.Sprintf("Total: %d", len(machineTypes)))
+
+	category := clustersmgmt.MachineTypeCategory("")
+	for _, machineType := range machineTypes {
+		if machineType.Category() != category {
+			category = machineType.Category()
+			parts = append(parts, fmt.Sprintf("--- %s ---", category))
+		}
+		parts = append(parts, fmt.Sprintf("%s - %g vCPU, %g GiB, %s",
+			machineType.ID(), ocm.MachineTypeCPU(machineType), ocm.MachineTypeMemoryGiB(machineType), machineType.Architecture()))
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "Note: arm64 instance types require a cluster created with multi_arch_enabled.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatVPCsResponse formats the VPCs of a region with their subnets and a subnet recommendation per VPC
+func formatVPCsResponse(region string, vpcs []*clustersmgmt.CloudVPC, private bool, zoneCount int) string {
+	var parts []string
+	parts = append(parts, "=== VPCs ===")
+	parts = append(parts, fmt.Sprintf("Region: %s", region))
+	parts = append(parts, fmt.Sprintf("Total: %d", len(vpcs)))
+
+	for _, vpc := range vpcs {
+		parts = append(parts, "")
+		title := vpc.ID()
+		if vpc.Name() != "" {
+			title = fmt.Sprintf("%s (%s)", vpc.ID(), vpc.Name())
+		}
+		parts = append(parts, fmt.Sprintf("--- %s ---", title))
+		if vpc.CIDRBlock() != "" {
+			parts = append(parts, fmt.Sprintf("CIDR: %s", vpc.CIDRBlock()))
+		}
+		if vpc.RedHatManaged() {
+			parts = append(parts, "Red Hat Managed: true")
+		}
+
+		subnets := vpc.AWSSubnets()
+		parts = append(parts, fmt.Sprintf("Subnets: %d", len(subnets)))
+		for _, subnet := range subnets {
+			visibility := "private"
+			if subnet.Public() {
+				visibility = "public"
+			}
+			line := fmt.Sprintf("  - %s: %s, %s, %s, needs tag %s=1", subnet.SubnetID(), subnet.AvailabilityZone(), visibility, subnet.CIDRBlock(), ocm.RequiredELBRoleTag(subnet))
+			if subnet.Name() != "" {
+				line += fmt.Sprintf(", name %s", subnet.Name())
+			}
+			if subnet.RedHatManaged() {
+				line += ", Red Hat managed"
+			}
+			parts = append(parts, line)
+		}
+
+		recommendation, err := ocm.RecommendSubnets(vpc, private, zoneCount)
+		if err != nil {
+			parts = append(parts, fmt.Sprintf("Recommendation: none, %v", err))
+			continue
+		}
+		clusterType := "public"
+		if recommendation.Private {
+			clusterType = "private"
+		}
+		parts = append(parts, fmt.Sprintf("Recommendation for a %s cluster:", clusterType))
+		parts = append(parts, fmt.Sprintf("  subnet_ids: %s", strings.Join(recommendation.SubnetIDs, ", ")))
+		parts = append(parts, fmt.Sprintf("  availability_zones: %s", strings.Join(recommendation.AvailabilityZones, ", ")))
+		if recommendation.MachineCIDR != "" {
+			parts = append(parts, fmt.Sprintf("  machine_cidr: %s", recommendation.MachineCIDR))
+		}
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("Note: OCM does not return subnet tags. Public subnets need the %s tag and private subnets the %s tag, both set to 1; check them with 'aws ec2 describe-subnets'.", ocm.ELBRoleTag, ocm.InternalELBRoleTag))
+	parts = append(parts, "Note: Subnets managed by Red Hat belong to other clusters and are never recommended.")
+	return strings.Join(parts, "\n")
+}
+
+// formatNetworkVerificationResponse formats the per-subnet results of a network verification
+func formatNetworkVerificationResponse(verification networkVerification, verifications []*clustersmgmt.SubnetNetworkVerification) string {
+	var parts []string
+	parts = append(parts, "=== Network Verification ===")
+	parts = append(parts, fmt.Sprintf("Verification ID: %s", verification.ID))
+	parts = append(parts, fmt.Sprintf("Region: %s", verification.Region))
+	parts = append(parts, fmt.Sprintf("Started: %s", verification.StartedAt.Format(time.RFC3339)))
+
+	done := ocm.NetworkVerificationDone(verifications)
+	passed := ocm.ValidateNetworkVerification(verifications, verification.SubnetIDs) == nil
+	switch {
+	case passed:
+		parts = append(parts, "✓ All subnets passed")
+	case done:
+		parts = append(parts, "✗ Verification failed")
+	default:
+		parts = append(parts, "… Verification in progress")
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "--- Subnets ---")
+	for _, subnet := range verifications {
+		parts = append(parts, fmt.Sprintf("  - %s: %s", subnet.ID(), subnet.State()))
+		if subnet.State() == ocm.NetworkVerificationFailed && len(subnet.Details()) > 0 {
+			parts = append(parts, "    Failing egress targets:")
+			for _, detail := range subnet.Details() {
+				parts = append(parts, fmt.Sprintf("      - %s", detail))
+			}
+		}
+	}
+
+	parts = append(parts, "")
+	switch {
+	case passed:
+		parts = append(parts, fmt.Sprintf("Note: Use network_verification_id %s with 'create_rosa_hcp_cluster'.", verification.ID))
+	case done:
+		parts = append(parts, "Note: Allow egress to the failing targets from the subnets, for example through the NAT gateway, security groups and firewall, then run 'verify_network' again.")
+	default:
+		parts = append(parts, fmt.Sprintf("Note: Call 'get_network_verification' with verification_id %s and wait set to true to wait for the results.", verification.ID))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// oidcConfigDetails returns the lines describing an OIDC configuration
+func oidcConfigDetails(config *clustersmgmt.OidcConfig) []string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("ID: %s", config.ID()))
+	if config.Managed() {
+		parts = append(parts, "Type: managed")
+	} else {
+		parts = append(parts, "Type: unmanaged")
+	}
+	parts = append(parts, fmt.Sprintf("Reusable: %t", config.Reusable()))
+	if config.IssuerUrl() != "" {
+		parts = append(parts, fmt.Sprintf("Issuer URL: %s", config.IssuerUrl()))
+	}
+	if config.InstallerRoleArn() != "" {
+		parts = append(parts, fmt.Sprintf("Installer Role ARN: %s", config.InstallerRoleArn()))
+	}
+	if created := config.CreationTimestamp(); !created.IsZero() {
+		parts = append(parts, fmt.Sprintf("Created: %s", created.Format(time.RFC3339)))
+	}
+	return parts
+}
+
+// formatOIDCConfigsResponse formats the OIDC configurations with the clusters using them
+func formatOIDCConfigsResponse(configs []*clustersmgmt.OidcConfig, usage map[string][]*clustersmgmt.Cluster) string {
+	var parts []string
+	parts = append(parts, "=== OIDC Configurations ===")
+
+	if len(configs) == 0 {
+		parts = append(parts, "No OIDC configurations found")
+		parts = append(parts, "")
+		parts = append(parts, "Note: Use 'create_oidc_config' to create a managed OIDC configuration.")
+		return strings.Join(parts, "\n")
+	}
+	parts = append(parts, fmt.Sprintf("Total: %d", len(configs)))
+
+	for _, config := range configs {
+		parts = append(parts, "")
+		parts = append(parts, oidcConfigDetails(config)...)
+		clusters := usage[config.ID()]
+		if len(clusters) == 0 {
+			parts = append(parts, "Used By: no clusters")
+			continue
+		}
+		names := make([]string, 0, len(clusters))
+		for _, cluster := range clusters {
+			names = append(names, fmt.Sprintf("%s (%s)", cluster.Name(), cluster.ID()))
+		}
+		parts = append(parts, fmt.Sprintf("Used By: %s", strings.Join(names, ", ")))
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "Note: Reusable configurations can be shared by several clusters; pass the ID as oidc_config_id to 'create_rosa_hcp_cluster'.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatOIDCConfigCreatedResponse formats a newly created OIDC configuration with the remaining AWS setup step
+func formatOIDCConfigCreatedResponse(config *clustersmgmt.OidcConfig) string {
+	var parts []string
+	parts = append(parts, "=== OIDC Configuration Created ===")
+	parts = append(parts, oidcConfigDetails(config)...)
+	parts = append(parts, "")
+	parts = append(parts, "Next step: register the OIDC provider in the AWS account that will host the cluster:")
+	parts = append(parts, fmt.Sprintf("  rosa create oidc-provider --oidc-config-id %s --mode auto", config.ID()))
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("Note: Use oidc_config_id %s with 'create_rosa_hcp_cluster'.", config.ID()))
+
+	return strings.Join(parts, "\n")
+}
+
+// formatOIDCConfigDeleteConfirmation formats the confirmation request for deleting an OIDC configuration
+func formatOIDCConfigDeleteConfirmation(config *clustersmgmt.OidcConfig, token string, expiresAt time.Time) string {
+	var parts []string
+	parts = append(parts, "=== OIDC Configuration Deletion Requested ===")
+	parts = append(parts, "⚠ The following OIDC configuration will be deleted. No cluster uses it:")
+	parts = append(parts, oidcConfigDetails(config)...)
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("Confirmation Token: %s", token))
+	parts = append(parts, fmt.Sprintf("Token Expires: %s", expiresAt.Format(time.RFC3339)))
+	parts = append(parts, "")
+	parts = append(parts, "Note: Nothing has been deleted yet. Only if the user explicitly confirms, call 'delete_oidc_config' again with this confirmation_token.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatAccountRolesValidation formats the outcome of an account role check, role by role
+func formatAccountRolesValidation(check *ocm.AccountRolesCheck) string {
+	var parts []string
+	parts = append(parts, "=== Account Role Validation ===")
+	parts = append(parts, fmt.Sprintf("AWS Account ID: %s", check.Spec.AccountID))
+	if problems := check.Problems(); problems == 0 {
+		parts = append(parts, "✓ No problems found")
+	} else {
+		parts = append(parts, fmt.Sprintf("✗ %d problem(s) found", problems))
+	}
+
+	if check.Spec.Version != "" {
+		parts = append(parts, "")
+		parts = append(parts, "--- Version ---")
+		parts = append(parts, fmt.Sprintf("Version: %s", check.Spec.Version))
+		if check.VersionProblem != "" {
+			parts = append(parts, fmt.Sprintf("✗ %s", check.VersionProblem))
+		} else {
+			parts = append(parts, "✓ Available for ROSA HCP")
+		}
+	}
+
+	for _, role := range check.Roles {
+		parts = append(parts, "")
+		parts = append(parts, fmt.Sprintf("--- %s Role (%s) ---", role.Role.Name, role.Role.Field))
+		if role.ARN != "" {
+			parts = append(parts, fmt.Sprintf("ARN: %s", role.ARN))
+		}
+		if role.Prefix != "" {
+			parts = append(parts, fmt.Sprintf("Prefix: %s", role.Prefix))
+		}
+		if role.PolicyARN != "" {
+			parts = append(parts, fmt.Sprintf("Required Managed Policy: %s", role.PolicyARN))
+		}
+		if len(role.Problems) == 0 && len(role.Warnings) == 0 {
+			parts = append(parts, "✓ OK")
+		}
+		for _, problem := range role.Problems {
+			parts = append(parts, fmt.Sprintf("✗ %s", problem))
+		}
+		for _, warning := range role.Warnings {
+			parts = append(parts, fmt.Sprintf("⚠ %s", warning))
+		}
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "Note: Role names and ARNs are checked, not the roles in AWS. Confirm the attached policies with 'aws iam list-attached-role-policies --role-name <role name>'.")
+	return strings.Join(parts, "\n")
+}
+
+// formatOperatorRolesResponse formats the operator roles of a cluster as a summary, aws iam commands or Terraform
+func formatOperatorRolesResponse(spec *ocm.OperatorRolesSpec, roles []*ocm.OperatorRole, format string) (string, error) {
+	if len(roles) == 0 {
+		return "", fmt.Errorf("OCM returned no operator credential requests for version '%s'", spec.Version)
+	}
+
+	var parts []string
+	switch format {
+	case "aws_cli":
+		parts = append(parts, fmt.Sprintf("# Operator roles for prefix '%s' in AWS account %s", spec.Prefix, spec.AccountID))
+		parts = append(parts, fmt.Sprintf("# The OIDC provider %s must exist in the account", spec.OIDCProviderARN()))
+		parts = append(parts, fmt.Sprintf("# Create the roles before calling create_rosa_hcp_cluster with operator_role_prefix '%s'", spec.Prefix))
+		for _, role := range roles {
+			commands, err := role.AWSCLICommands()
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, "")
+			parts = append(parts, fmt.Sprintf("# %s/%s", role.Namespace, role.OperatorName))
+			parts = append(parts, commands...)
+		}
+	case "terraform":
+		parts = append(parts, fmt.Sprintf("# Operator roles for prefix '%s' in AWS account %s", spec.Prefix, spec.AccountID))
+		parts = append(parts, fmt.Sprintf("# The OIDC provider %s must exist in the account", spec.OIDCProviderARN()))
+		parts = append(parts, fmt.Sprintf("# Create the roles before calling create_rosa_hcp_cluster with operator_role_prefix '%s'", spec.Prefix))
+		parts = append(parts, "")
+		parts = append(parts, ocm.OperatorRolesTerraform(roles))
+	case "summary":
+		parts = append(parts, "=== Operator Roles ===")
+		parts = append(parts, fmt.Sprintf("Prefix: %s", spec.Prefix))
+		parts = append(parts, fmt.Sprintf("AWS Account ID: %s", spec.AccountID))
+		parts = append(parts, fmt.Sprintf("OIDC Provider: %s", spec.OIDCProviderARN()))
+		if spec.Version != "" {
+			parts = append(parts, fmt.Sprintf("Version: %s", spec.Version))
+		}
+		parts = append(parts, fmt.Sprintf("Roles: %d", len(roles)))
+		for _, role := range roles {
+			parts = append(parts, "")
+			parts = append(parts, fmt.Sprintf("--- %s ---", role.RoleName))
+			parts = append(parts, fmt.Sprintf("Operator: %s/%s", role.Namespace, role.OperatorName))
+			parts = append(parts, fmt.Sprintf("Role ARN: %s", role.RoleARN))
+			parts = append(parts, fmt.Sprintf("Service Accounts: %s", strings.Join(role.ServiceAccounts, ", ")))
+			parts = append(parts, fmt.Sprintf("Permission Policies: %s", strings.Join(role.PolicyARNs, ", ")))
+			parts = append(parts, "Trust Policy:")
+			parts = append(parts, role.TrustPolicy)
+		}
+		parts = append(parts, "")
+		parts = append(parts, "Note: Use format aws_cli or terraform to get commands that create these roles.")
+		parts = append(parts, fmt.Sprintf("Note: Create the roles before calling 'create_rosa_hcp_cluster' with operator_role_prefix '%s'.", spec.Prefix))
+	default:
+		return "", fmt.Errorf("invalid format '%s': must be one of summary, aws_cli, terraform", format)
+	}
+
+	return strings.Join(parts, "\n"), nil
+}
+
+// formatVersionGatesResponse formats the unacknowledged version gates blocking an upgrade for display
+func formatVersionGatesResponse(cluster *clustersmgmt.Cluster, version string, gates []*clustersmgmt.VersionGate, token string, expiresAt time.Time) string {
+	var parts []string
+	parts = append(parts, "=== Upgrade Not Scheduled: Version Gates Require Acknowledgement ===")
+	parts = append(parts, fmt.Sprintf("Cluster: %s (%s)", cluster.Name(), cluster.ID()))
+	parts = append(parts, fmt.Sprintf("Upgrade: %s -> %s", cluster.Version().RawID(), version))
+
+	for _, gate := range gates {
+		parts = append(parts, "---")
+		parts = append(parts, fmt.Sprintf("Gate ID: %s", gate.ID()))
+		if label := gate.Label(); label != "" {
+			parts = append(parts, fmt.Sprintf("Label: %s", label))
+		}
+		if description := gate.Description(); description != "" {
+			parts = append(parts, fmt.Sprintf("Description: %s", description))
+		}
+		if warning := gate.WarningMessage(); warning != "" {
+			parts = append(parts, fmt.Sprintf("Warning: %s", warning))
+		}
+		if docURL := gate.DocumentationURL(); docURL != "" {
+			parts = append(parts, fmt.Sprintf("Documentation: %s", docURL))
+		}
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("Confirmation Token: %s", token))
+	parts = append(parts, fmt.Sprintf("Token Expires: %s", expiresAt.Format(time.RFC3339)))
+	parts = append(parts, "")
+	parts = append(parts, "Note: Nothing has been acknowledged or scheduled yet. Review these gates with the user. Only if they accept them, call 'schedule_upgrade' again with the same arguments and this confirmation_token.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatUpgradePolicyCreatedResponse formats a newly created upgrade policy for display
+func formatUpgradePolicyCreatedResponse(cluster *clustersmgmt.Cluster, nodePoolID string, policy upgradePolicy) string {
+	target := "Control Plane"
+	if nodePoolID != "" {
+		target = fmt.Sprintf("Node Pool %s", nodePoolID)
+	}
+
+	var parts []string
+	parts = append(parts, "=== Upgrade Scheduled ===")
+	parts = append(parts, fmt.Sprintf("Cluster: %s (%s)", cluster.Name(), cluster.ID()))
+	parts = append(parts, fmt.Sprintf("Target: %s", target))
+	parts = append(parts, upgradePolicyDetails(policy)...)
+	parts = append(parts, "")
+	parts = append(parts, "Note: Use 'list_upgrade_policies' to track progress or 'cancel_upgrade' to cancel before it starts.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatUpgradePoliciesResponse formats the upgrade policies of a cluster for display
+func formatUpgradePoliciesResponse(clusterID string, sections []upgradePolicySection) string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("=== Upgrade Policies for cluster %s ===", clusterID))
+
+	for _, section := range sections {
+		parts = append(parts, fmt.Sprintf("--- %s ---", section.title))
+		if len(section.policies) == 0 {
+			parts = append(parts, "No upgrade policies")
+			continue
+		}
+		for i, policy := range section.policies {
+			if i > 0 {
+				parts = append(parts, "")
+			}
+			parts = append(parts, upgradePolicyDetails(policy)...)
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// formatClusterLogsResponse formats cluster install or uninstall logs for display
+func formatClusterLogsResponse(clusterID string, logType ocm.LogType, content string, note string) string {
+	title := "Install"
+	if logType == ocm.UninstallLog {
+		title = "Uninstall"
+	}
+
+	var parts []string
+	parts = append(parts, fmt.Sprintf("=== %s Logs for cluster %s ===", title, clusterID))
+
+	if strings.TrimSpace(content) == "" {
+		parts = append(parts, "No log lines available")
+	} else {
+		parts = append(parts, strings.TrimRight(content, "\n"))
+	}
+
+	if note != "" {
+		parts = append(parts, "")
+		parts = append(parts, "Note: "+note)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// formatClusterWaitResponse formats the outcome of waiting for a cluster state for display
+func formatClusterWaitResponse(cluster *clustersmgmt.Cluster, waited time.Duration, note string) string {
+	var parts []string
+	if note == "" {
+		parts = append(parts, fmt.Sprintf("✓ Cluster reached state '%s' (waited %s)", cluster.State(), waited.Round(time.Second)))
+	} else {
+		parts = append(parts, note)
+	}
+
+	if status := cluster.Status(); status != nil {
+		if description := status.Description(); description != "" {
+			parts = append(parts, fmt.Sprintf("Status Description: %s", description))
+		}
+		if code := status.ProvisionErrorCode(); code != "" {
+			parts = append(parts, fmt.Sprintf("Provision Error Code: %s", code))
+		}
+		if message := status.ProvisionErrorMessage(); message != "" {
+			parts = append(parts, fmt.Sprintf("Provision Error Message: %s", message))
+		}
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, formatClusterResponse(cluster))
+
+	return strings.Join(parts, "\n")
+}
+
+// formatClusterAdminCreatedResponse formats newly created cluster admin credentials for display
+func formatClusterAdminCreatedResponse(cluster *clustersmgmt.Cluster, credentials *ocm.ClusterAdminCredentials) string {
+	apiURL := "<api_url>"
+	if api := cluster.API(); api != nil && api.URL() != "" {
+		apiURL = api.URL()
+	}
+
+	var parts []string
+	parts = append(parts, "=== Cluster Admin Created ===")
+	parts = append(parts, fmt.Sprintf("Cluster: %s (%s)", cluster.Name(), cluster.ID()))
+	parts = append(parts, fmt.Sprintf("Identity Provider: %s", credentials.IdentityProvider.Name()))
+	parts = append(parts, fmt.Sprintf("Group: %s", ocm.ClusterAdminsGroup))
+	parts = append(parts, fmt.Sprintf("Username: %s", credentials.Username))
+	parts = append(parts, fmt.Sprintf("Password: %s", credentials.Password))
+	parts = append(parts, "")
+	parts = append(parts, "Log in with:")
+	parts = append(parts, fmt.Sprintf("oc login %s --username %s --password %s", apiURL, credentials.Username, credentials.Password))
+	parts = append(parts, "")
+	parts = append(parts, "Note: This password is shown only once and cannot be retrieved again. It may take a few minutes before the user can log in.")
+	parts = append(parts, "Use 'delete_cluster_admin' to revoke this user once a permanent identity provider is configured.")
+
+	return strings.Join(parts, "\n")
+}
+
+// idpTypeDisplayName returns a human readable name for an identity provider type
+func idpTypeDisplayName(idpType clustersmgmt.IdentityProviderType) string {
+	switch idpType {
+	case clustersmgmt.IdentityProviderTypeHtpasswd:
+		return "HTPasswd"
+	case clustersmgmt.IdentityProviderTypeGithub:
+		return "GitHub"
+	case clustersmgmt.IdentityProviderTypeGitlab:
+		return "GitLab"
+	case clustersmgmt.IdentityProviderTypeGoogle:
+		return "Google"
+	case clustersmgmt.IdentityProviderTypeOpenID:
+		return "OpenID"
+	case clustersmgmt.IdentityProviderTypeLDAP:
+		return "LDAP"
+	default:
+		return string(idpType)
+	}
+}
+
+// htpasswdUsernames returns the sorted usernames of HTPasswd users; password hashes are never exposed
+func htpasswdUsernames(users []*clustersmgmt.HTPasswdUser) []string {
+	usernames := make([]string, 0, len(users))
+	for _, user := range users {
+		usernames = append(usernames, user.Username())
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+// identityProviderDetails returns the display lines describing an identity provider.
+// Client secrets, bind passwords and password hashes are never included.
+func identityProviderDetails(idp *clustersmgmt.IdentityProvider, users []*clustersmgmt.HTPasswdUser) []string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("Name: %s", idp.Name()))
+	parts = append(parts, fmt.Sprintf("ID: %s", idp.ID()))
+	parts = append(parts, fmt.Sprintf("Type: %s", idpTypeDisplayName(idp.Type())))
+	parts = append(parts, fmt.Sprintf("Mapping Method: %s", idp.MappingMethod()))
+
+	switch idp.Type() {
+	case clustersmgmt.IdentityProviderTypeHtpasswd:
+		usernames := htpasswdUsernames(users)
+		parts = append(parts, fmt.Sprintf("Users (%d): %s", len(usernames), strings.Join(usernames, ", ")))
+	case clustersmgmt.IdentityProviderTypeGithub:
+		if github := idp.Github(); github != nil {
+			parts = append(parts, fmt.Sprintf("Client ID: %s", github.ClientID()))
+			if hostname := github.Hostname(); hostname != "" {
+				parts = append(parts, fmt.Sprintf("Hostname: %s", hostname))
+			}
+			if orgs := github.Organizations(); len(orgs) > 0 {
+				parts = append(parts, fmt.Sprintf("Organizations: %s", strings.Join(orgs, ", ")))
+			}
+			if teams := github.Teams(); len(teams) > 0 {
+				parts = append(parts, fmt.Sprintf("Teams: %s", strings.Join(teams, ", ")))
+			}
+		}
+	case clustersmgmt.IdentityProviderTypeGitlab:
+		if gitlab := idp.Gitlab(); gitlab != nil {
+			parts = append(parts, fmt.Sprintf("URL: %s", gitlab.URL()))
+			parts = append(parts, fmt.Sprintf("Client ID: %s", gitlab.ClientID()))
+		}
+	case clustersmgmt.IdentityProviderTypeGoogle:
+		if google := idp.Google(); google != nil {
+			parts = append(parts, fmt.Sprintf("Client ID: %s", google.ClientID()))
+			if hostedDomain := google.HostedDomain(); hostedDomain != "" {
+				parts = append(parts, fmt.Sprintf("Hosted Domain: %s", hostedDomain))
+			}
+		}
+	case clustersmgmt.IdentityProviderTypeOpenID:
+		if openID := idp.OpenID(); openID != nil {
+			parts = append(parts, fmt.Sprintf("Issuer: %s", openID.Issuer()))
+			parts = append(parts, fmt.Sprintf("Client ID: %s", openID.ClientID()))
+			if claims := openID.Claims(); claims != nil {
+				if values := claims.PreferredUsername(); len(values) > 0 {
+					parts = append(parts, fmt.Sprintf("Username Claims: %s", strings.Join(values, ", ")))
+				}
+				if values := claims.Email(); len(values) > 0 {
+					parts = append(parts, fmt.Sprintf("Email Claims: %s", strings.Join(values, ", ")))
+				}
+				if values := claims.Name(); len(values) > 0 {
+					parts = append(parts, fmt.Sprintf("Name Claims: %s", strings.Join(values, ", ")))
+				}
+				if values := claims.Groups(); len(values) > 0 {
+					parts = append(parts, fmt.Sprintf("Groups Claims: %s", strings.Join(values, ", ")))
+				}
+			}
+		}
+	case clustersmgmt.IdentityProviderTypeLDAP:
+		if ldap := idp.LDAP(); ldap != nil {
+			parts = append(parts, fmt.Sprintf("URL: %s", ldap.URL()))
+			if bindDN := ldap.BindDN(); bindDN != "" {
+				parts = append(parts, fmt.Sprintf("Bind DN: %s", bindDN))
+			}
+			parts = append(parts, fmt.Sprintf("Insecure: %t", ldap.Insecure()))
+			if attributes := ldap.Attributes(); attributes != nil {
+				if values := attributes.ID(); len(values) > 0 {
+					parts = append(parts, fmt.Sprintf("ID Attributes: %s", strings.Join(values, ", ")))
+				}
+				if values := attributes.PreferredUsername(); len(values) > 0 {
+					parts = append(parts, fmt.Sprintf("Username Attributes: %s", strings.Join(values, ", ")))
+				}
+				if values := attributes.Email(); len(values) > 0 {
+					parts = append(parts, fmt.Sprintf("Email Attributes: %s", strings.Join(values, ", ")))
+				}
+				if values := attributes.Name(); len(values) > 0 {
+					parts = append(parts, fmt.Sprintf("Name Attributes: %s", strings.Join(values, ", ")))
+				}
+			}
+		}
+	}
+
+	return parts
+}
+
+// formatIdentityProvidersResponse formats a cluster's identity provider list for display
+func formatIdentityProvidersResponse(
+	clusterID string,
+	idps []*clustersmgmt.IdentityProvider,
+	htpasswdUsers map[string][]*clustersmgmt.HTPasswdUser,
+) string {
+	if len(idps) == 0 {
+		return fmt.Sprintf("No identity providers configured for cluster %s", clusterID)
+	}
+
+	var parts []string
+	parts = append(parts, fmt.Sprintf("=== Identity Providers for cluster %s (%d found) ===", clusterID, len(idps)))
+
+	for i, idp := range idps {
+		if i > 0 {
+			parts = append(parts, "---")
+		}
+		parts = append(parts, identityProviderDetails(idp, htpasswdUsers[idp.ID()])...)
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// formatIdentityProviderResponse formats a single identity provider for display
+func formatIdentityProviderResponse(clusterID string, idp *clustersmgmt.IdentityProvider, users []*clustersmgmt.HTPasswdUser) string {
+	var parts []string
+	parts = append(parts, "=== Identity Provider Details ===")
+	parts = append(parts, fmt.Sprintf("Cluster: %s", clusterID))
+	parts = append(parts, identityProviderDetails(idp, users)...)
+
+	return strings.Join(parts, "\n")
+}
+
+// formatIdentityProviderDeleteConfirmation formats the summary shown before an identity provider is deleted
+func formatIdentityProviderDeleteConfirmation(clusterID string, idp *clustersmgmt.IdentityProvider, token string, expiresAt time.Time) string {
+	var parts []string
+	parts = append(parts, "=== Identity Provider Deletion Requested ===")
+	parts = append(parts, "⚠ The following identity provider will be deleted and its users will no longer be able to log in:")
+	parts = append(parts, fmt.Sprintf("Cluster: %s", clusterID))
+	parts = append(parts, fmt.Sprintf("Name: %s", idp.Name()))
+	parts = append(parts, fmt.Sprintf("ID: %s", idp.ID()))
+	parts = append(parts, fmt.Sprintf("Type: %s", idpTypeDisplayName(idp.Type())))
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("Confirmation Token: %s", token))
+	parts = append(parts, fmt.Sprintf("Token Expires: %s", expiresAt.Format(time.RFC3339)))
+	parts = append(parts, "")
+	parts = append(parts, "Note: Nothing has been deleted yet. Only if the user explicitly confirms, call 'delete_identity_provider' again with this confirmation_token.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatHTPasswdUsersResponse formats the users of an HTPasswd identity provider for display
+func formatHTPasswdUsersResponse(clusterID string, idp *clustersmgmt.IdentityProvider, users []*clustersmgmt.HTPasswdUser) string {
+	if len(users) == 0 {
+		return fmt.Sprintf("No users found in identity provider '%s' on cluster %s", idp.Name(), clusterID)
+	}
+
+	sorted := make([]*clustersmgmt.HTPasswdUser, len(users))
+	copy(sorted, users)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Username() < sorted[j].Username()
+	})
+
+	var parts []string
+	parts = append(parts, fmt.Sprintf("=== Users of identity provider '%s' on cluster %s (%d found) ===", idp.Name(), clusterID, len(sorted)))
+	for _, user := range sorted {
+		parts = append(parts, fmt.Sprintf("- %s (ID: %s)", user.Username(), user.ID()))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// formatHTPasswdUserRemoveConfirmation formats the summary shown before an HTPasswd user is removed
+func formatHTPasswdUserRemoveConfirmation(clusterID string, idp *clustersmgmt.IdentityProvider, username string, token string, expiresAt time.Time) string {
+	var parts []string
+	parts = append(parts, "=== HTPasswd User Removal Requested ===")
+	parts = append(parts, "⚠ The following user will be removed and will no longer be able to log in:")
+	parts = append(parts, fmt.Sprintf("Cluster: %s", clusterID))
+	parts = append(parts, fmt.Sprintf("Identity Provider: %s", idp.Name()))
+	parts = append(parts, fmt.Sprintf("Username: %s", username))
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("Confirmation Token: %s", token))
+	parts = append(parts, fmt.Sprintf("Token Expires: %s", expiresAt.Format(time.RFC3339)))
+	parts = append(parts, "")
+	parts = append(parts, "Note: Nothing has been removed yet. Only if the user explicitly confirms, call 'remove_htpasswd_user' again with this confirmation_token.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatHTPasswdPasswordResetResponse formats the result of an HTPasswd password reset.
+// The password is only shown when it was generated by the server.
+func formatHTPasswdPasswordResetResponse(clusterID string, idp *clustersmgmt.IdentityProvider, username string, password string, generated bool) string {
+	var parts []string
+	parts = append(parts, "=== Password Reset ===")
+	parts = append(parts, fmt.Sprintf("Cluster: %s", clusterID))
+	parts = append(parts, fmt.Sprintf("Identity Provider: %s", idp.Name()))
+	parts = append(parts, fmt.Sprintf("Username: %s", username))
+	if generated {
+		parts = append(parts, fmt.Sprintf("Password: %s", password))
+		parts = append(parts, "")
+		parts = append(parts, "Note: This password is shown only once and cannot be retrieved again.")
+	}
+	parts = append(parts, "Note: It may take a few minutes before the new password takes effect.")
+
+	return strings.Join(parts, "\n")
+}
+
+// formatIdentityProviderCreatedResponse formats the result of setting up an identity provider,
+// including the OAuth callback URL that has to be registered with the provider
+func formatIdentityProviderCreatedResponse(cluster *clustersmgmt.Cluster, idp *clustersmgmt.IdentityProvider) string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("=== %s Identity Provider Created ===", idpTypeDisplayName(idp.Type())))
+	parts = append(parts, fmt.Sprintf("Cluster: %s (%s)", cluster.Name(), cluster.ID()))
+	parts = append(parts, identityProviderDetails(idp, nil)...)
+
+	// LDAP authenticates directly against the directory and has no OAuth redirect
+	if idp.Type() != clustersmgmt.IdentityProviderTypeLDAP {
+		parts = append(parts, "")
+		if callbackURL := ocm.OAuthCallbackURL(cluster, idp.Name()); callbackURL != "" {
+			parts = append(parts, fmt.Sprintf("OAuth Callback URL: %s", callbackURL))
+			parts = append(parts, "Register this callback URL with the OAuth application before users log in.")
+		} else {
+			parts = append(parts, "OAuth Callback URL: not available until the cluster API URL is known.")
+			parts = append(parts, fmt.Sprintf("It will have the form https://oauth.<cluster-domain>/oauth2callback/%s", idp.Name()))
+		}
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "Note: It may take a few minutes before users can log in with this identity provider.")
+
+	return strings.Join(parts, "\n")
+}
+
+// FormatHTPasswdIdentityProviderResult - Enhanced with ROSA CLI patterns
+func FormatHTPasswdIdentityProviderResult(
+	result *ocm.HTPasswdSetupResult,
+	cluster *clustersmgmt.Cluster,
+) string {
+	var output strings.Builder
+	idp := result.IdentityProvider
+
+	output.WriteString("HTPasswd Identity Provider Setup Complete\n\n")
+
+	// Provider Details (similar to ROSA CLI output format)
+	output.WriteString("Provider Details:\n")
+	output.WriteString(fmt.Sprintf("- Name: %s\n", idp.Name()))
+	output.WriteString("- Type: HTPasswd\n")
+	output.WriteString(fmt.Sprintf("- Mapping Method: %s\n", idp.MappingMethod()))
+	if result.Overwritten {
+		output.WriteString(fmt.Sprintf("- Overwrite Mode: %s\n", result.Mode))
+		output.WriteString(fmt.Sprintf("- Users Added (%d): %s\n", len(result.Added), strings.Join(result.Added, ", ")))
+		output.WriteString(fmt.Sprintf("- Users Updated (%d): %s\n", len(result.Updated), strings.Join(result.Updated, ", ")))
+		output.WriteString(fmt.Sprintf("- Users Removed (%d): %s\n", len(result.Removed), strings.Join(result.Removed, ", ")))
+	} else {
+		output.WriteString(fmt.Sprintf("- Users Created: %d\n", len(result.Added)))
+	}
 	output.WriteString(fmt.Sprintf("- Cluster: %s (%s)\n", cluster.Name(), cluster.ID()))
 	output.WriteString(fmt.Sprintf("- Status: %s\n", idp.Type()))
 
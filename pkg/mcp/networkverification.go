@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// networkVerificationTTL is how long a network verification ID can be referenced after it was started
+const networkVerificationTTL = 24 * time.Hour
+
+// networkVerification records the subnets a verify_network call verified
+type networkVerification struct {
+	ID        string
+	RoleARN   string
+	Region    string
+	SubnetIDs []string
+	StartedAt time.Time
+	expiresAt time.Time
+}
+
+// networkVerificationStore maps the IDs returned by verify_network to the verified subnets.
+// OCM tracks network verifications per subnet, the ID groups the subnets of one verify_network call.
+type networkVerificationStore struct {
+	mu            sync.Mutex
+	verifications map[string]networkVerification
+	ttl           time.Duration
+	now           func() time.Time
+}
+
+// newNetworkVerificationStore creates a network verification store with the given ID lifetime
+func newNetworkVerificationStore(ttl time.Duration) *networkVerificationStore {
+	return &networkVerificationStore{
+		verifications: make(map[string]networkVerification),
+		ttl:           ttl,
+		now:           time.Now,
+	}
+}
+
+// Add records a verification of the given subnets and returns it with its new ID
+func (ns *networkVerificationStore) Add(roleARN, region string, subnetIDs []string) (networkVerification, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return networkVerification{}, fmt.Errorf("failed to generate network verification ID: %w", err)
+	}
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	now := ns.now()
+	ns.purgeExpired(now)
+
+	verification := networkVerification{
+		ID:        "nv-" + hex.EncodeToString(buf),
+		RoleARN:   roleARN,
+		Region:    region,
+		SubnetIDs: append([]string(nil), subnetIDs...),
+		StartedAt: now,
+		expiresAt: now.Add(ns.ttl),
+	}
+	ns.verifications[verification.ID] = verification
+	return verification, nil
+}
+
+// Get returns the verification with the given ID
+func (ns *networkVerificationStore) Get(id string) (networkVerification, error) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	verification, exists := ns.verifications[id]
+	if !exists || ns.now().After(verification.expiresAt) {
+		return networkVerification{}, fmt.Errorf("unknown or expired network verification ID '%s'; run verify_network again", id)
+	}
+	return verification, nil
+}
+
+// purgeExpired removes expired verifications; callers must hold ns.mu
+func (ns *networkVerificationStore) purgeExpired(now time.Time) {
+	for id, verification := range ns.verifications {
+		if now.After(verification.expiresAt) {
+			delete(ns.verifications, id)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tiwillia/rosa-mcp-go/pkg/ocm"
+)
+
+func TestNetworkVerificationStore(t *testing.T) {
+	t.Run("verification can be looked up by ID", func(t *testing.T) {
+		ns := newNetworkVerificationStore(time.Hour)
+		subnetIDs := []string{"subnet-1", "subnet-2"}
+		verification, err := ns.Add("arn:aws:iam::123456789012:role/Installer-Role", "us-east-1", subnetIDs)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(verification.ID, "nv-"))
+
+		// The store keeps its own copy of the subnets
+		subnetIDs[0] = "subnet-3"
+
+		found, err := ns.Get(verification.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "us-east-1", found.Region)
+		assert.Equal(t, []string{"subnet-1", "subnet-2"}, found.SubnetIDs)
+
+		// IDs can be referenced more than once
+		_, err = ns.Get(verification.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown ID is rejected", func(t *testing.T) {
+		ns := newNetworkVerificationStore(time.Hour)
+		_, err := ns.Get("nv-does-not-exist")
+		assert.ErrorContains(t, err, "run verify_network again")
+	})
+
+	t.Run("expired ID is rejected", func(t *testing.T) {
+		ns := newNetworkVerificationStore(time.Hour)
+		now := time.Now()
+		ns.now = func() time.Time { return now }
+
+		verification, err := ns.Add("arn:aws:iam::123456789012:role/Installer-Role", "us-east-1", []string{"subnet-1"})
+		assert.NoError(t, err)
+
+		ns.now = func() time.Time { return now.Add(2 * time.Hour) }
+		_, err = ns.Get(verification.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckNetworkVerificationRejectsMismatches(t *testing.T) {
+	s := &Server{networkVerifications: newNetworkVerificationStore(time.Hour)}
+	verification, err := s.networkVerifications.Add("arn:aws:iam::123456789012:role/Installer-Role", "us-east-1", []string{"subnet-1", "subnet-2"})
+	assert.NoError(t, err)
+
+	spec := &ocm.ClusterCreateSpec{AWSAccountID: "123456789012", Region: "us-east-1", SubnetIDs: []string{"subnet-1", "subnet-2"}}
+
+	// Mismatches are found before OCM is contacted, so no client is needed
+	otherRegion := *spec
+	otherRegion.Region = "us-west-2"
+	assert.ErrorContains(t, s.checkNetworkVerification(nil, verification.ID, &otherRegion), "region")
+
+	otherAccount := *spec
+	otherAccount.AWSAccountID = "210987654321"
+	assert.ErrorContains(t, s.checkNetworkVerification(nil, verification.ID, &otherAccount), "another AWS account")
+
+	otherSubnets := *spec
+	otherSubnets.SubnetIDs = []string{"subnet-1", "subnet-3"}
+	assert.ErrorContains(t, s.checkNetworkVerification(nil, verification.ID, &otherSubnets), "subnet-3")
+}
@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// parseStringArray extracts an array of strings from tool arguments, ignoring non-string items
+func parseStringArray(args map[string]interface{}, key string) []string {
+	values := make([]string, 0)
+	if arg, ok := args[key].([]interface{}); ok {
+		for _, item := range arg {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+	}
+	return values
+}
+
+// parseOptionalInt extracts an optional integer argument; returns nil if the argument is absent
+func parseOptionalInt(args map[string]interface{}, key string) (*int, error) {
+	raw, exists := args[key]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	switch v := raw.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return nil, fmt.Errorf("invalid argument %s: expected an integer, got %v", key, v)
+		}
+		i := int(v)
+		return &i, nil
+	case int:
+		return &v, nil
+	default:
+		return nil, fmt.Errorf("invalid argument %s: expected a number, got %T", key, raw)
+	}
+}
+
+// parseOptionalBool extracts an optional boolean argument; returns nil if the argument is absent
+func parseOptionalBool(args map[string]interface{}, key string) (*bool, error) {
+	raw, exists := args[key]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	b, ok := raw.(bool)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument %s: expected a boolean, got %T", key, raw)
+	}
+	return &b, nil
+}
+
+// parseOptionalTime extracts an optional RFC 3339 timestamp or YYYY-MM-DD date argument; returns nil if
+// the argument is absent. A date is midnight UTC, or the last instant of that day with endOfDay, so an
+// upper bound given as a date includes the whole day.
+func parseOptionalTime(args map[string]interface{}, key string, endOfDay bool) (*time.Time, error) {
+	raw, exists := args[key]
+	if !exists || raw == nil || raw == "" {
+		return nil, nil
+	}
+
+	str, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid argument %s: expected a string, got %T", key, raw)
+	}
+	if t, err := time.Parse(time.RFC3339, str); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, str); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		return &t, nil
+	}
+	return nil, fmt.Errorf("invalid argument %s: expected an RFC 3339 timestamp or a YYYY-MM-DD date, got '%s'", key, str)
+}
+
+// parseStringMap extracts an object of string values from tool arguments; returns nil if the argument is absent
+func parseStringMap(args map[string]interface{}, key string) (map[string]string, error) {
+	raw, exists := args[key]
+	if !exists || raw == nil {
+		return nil, nil
+	}
+
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid argument %s: expected an object of string values", key)
+	}
+
+	result := make(map[string]string, len(obj))
+	for k, v := range obj {
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid argument %s: value for '%s' must be a string", key, k)
+		}
+		result[k] = str
+	}
+	return result, nil
+}
+
+// redactArgs returns a copy of the tool arguments with the given secret values masked, for logging
+func redactArgs(args map[string]interface{}, keys ...string) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		redacted[k] = v
+	}
+	for _, key := range keys {
+		if _, exists := redacted[key]; exists {
+			redacted[key] = "<redacted>"
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOptionalTime(t *testing.T) {
+	args := map[string]interface{}{
+		"timestamp": "2026-10-17T08:30:00Z",
+		"date":      "2026-10-17",
+		"invalid":   "17/10/2026",
+	}
+
+	parsed, err := parseOptionalTime(args, "timestamp", true)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 17, 8, 30, 0, 0, time.UTC), *parsed)
+
+	parsed, err = parseOptionalTime(args, "date", false)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), *parsed)
+
+	// An upper bound given as a date includes the whole day
+	parsed, err = parseOptionalTime(args, "date", true)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 17, 23, 59, 59, 999999999, time.UTC), *parsed)
+
+	parsed, err = parseOptionalTime(args, "missing", true)
+	assert.NoError(t, err)
+	assert.Nil(t, parsed)
+
+	_, err = parseOptionalTime(args, "invalid", false)
+	assert.ErrorContains(t, err, "YYYY-MM-DD")
+}
@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter sends MCP progress notifications for a long-running tool call.
+// Notifications are only sent when the client supplied a progress token with the request.
+type progressReporter struct {
+	mcpServer *server.MCPServer
+	token     mcp.ProgressToken
+	progress  float64
+}
+
+// newProgressReporter creates a progress reporter for the given tool call
+func (s *Server) newProgressReporter(ctr mcp.CallToolRequest) *progressReporter {
+	reporter := &progressReporter{mcpServer: s.mcpServer}
+	if ctr.Params.Meta != nil {
+		reporter.token = ctr.Params.Meta.ProgressToken
+	}
+	return reporter
+}
+
+// Report sends a progress notification with the given message
+func (p *progressReporter) Report(ctx context.Context, message string) {
+	if p.token == nil {
+		return
+	}
+
+	p.progress++
+	err := p.mcpServer.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": p.token,
+		"progress":      p.progress,
+		"message":       message,
+	})
+	if err != nil {
+		glog.V(2).Infof("Failed to send progress notification: %v", err)
+	}
+}
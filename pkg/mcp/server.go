@@ -13,15 +13,19 @@ import (
 
 // Server represents the MCP server
 type Server struct {
-	mcpServer *server.MCPServer
-	ocmClient *ocm.Client
-	config    *config.Configuration
+	mcpServer            *server.MCPServer
+	ocmClient            *ocm.Client
+	config               *config.Configuration
+	confirmations        *confirmationStore
+	networkVerifications *networkVerificationStore
 }
 
 // NewServer creates a new MCP server
 func NewServer(cfg *config.Configuration) *Server {
 	s := &Server{
-		config: cfg,
+		config:               cfg,
+		confirmations:        newConfirmationStore(confirmationTTL),
+		networkVerifications: newNetworkVerificationStore(networkVerificationTTL),
 	}
 
 	// Create MCP server following OpenShift MCP patterns
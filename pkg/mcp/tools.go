@@ -3,10 +3,18 @@ package mcp
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	clustersmgmt "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/tiwillia/rosa-mcp-go/pkg/htpasswd"
 	"github.com/tiwillia/rosa-mcp-go/pkg/ocm"
+	"github.com/tiwillia/rosa-mcp-go/pkg/validation"
 )
 
 // initTools returns the ROSA HCP tools
@@ -20,8 +28,19 @@ func (s *Server) initTools() []server.ServerTool {
 		), Handler: s.handleWhoami},
 
 		{Tool: mcp.NewTool("get_clusters",
-			mcp.WithDescription("Retrieves the list of clusters"),
-			mcp.WithString("state", mcp.Description("Filter clusters by state (e.g., ready, installing, error)"), mcp.Required()),
+			mcp.WithDescription("Retrieves the list of clusters. All filters are optional and combined; without filters every cluster of the organization is listed."),
+			mcp.WithString("search", mcp.Description("Only clusters whose name or ID contains this text, ignoring case")),
+			mcp.WithArray("states", mcp.Description("Only clusters in one of these states: "+strings.Join(ocm.ValidClusterStates, ", "))),
+			mcp.WithString("region", mcp.Description("Only clusters in this AWS region, e.g. us-east-1")),
+			mcp.WithString("version", mcp.Description("Only clusters on this OpenShift version, e.g. 4.16.3, or any release of a minor version, e.g. 4.16")),
+			mcp.WithString("product", mcp.Description("Only clusters of this product, e.g. rosa or osd")),
+			mcp.WithBoolean("hypershift", mcp.Description("Only clusters with (true) or without (false) hosted control planes")),
+			mcp.WithString("creator", mcp.Description("Only clusters whose creator ARN contains this text, e.g. an AWS account ID or IAM user name")),
+			mcp.WithString("created_after", mcp.Description("Only clusters created at or after this time, as an RFC 3339 timestamp or YYYY-MM-DD date")),
+			mcp.WithString("created_before", mcp.Description("Only clusters created at or before this time, as an RFC 3339 timestamp or YYYY-MM-DD date (a date includes the whole day)")),
+			mcp.WithString("sort_by", mcp.Description("Field to sort the clusters by"), mcp.Enum(ocm.ValidClusterSortFields...)),
+			mcp.WithString("sort_order", mcp.Description("Sort direction for sort_by"), mcp.Enum("asc", "desc"), mcp.DefaultString("asc")),
+			mcp.WithArray("fields", mcp.Description("Fields to show for each cluster: "+strings.Join(validClusterFields, ", ")+". Defaults to "+strings.Join(defaultClusterFields, ", "))),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
@@ -35,27 +54,29 @@ func (s *Server) initTools() []server.ServerTool {
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.handleGetCluster},
 
-		{Tool: mcp.NewTool("create_rosa_hcp_cluster",
-			mcp.WithDescription(`Provision a new ROSA HCP cluster with basic configuration.
+		{Tool: mcp.NewTool("create_rosa_hcp_cluster", clusterSpecToolOptions(
+			mcp.WithDescription(`Provision a new ROSA HCP cluster.
+
+Only the identity, role and networking parameters are required. The OpenShift version, compute instance type, node count or autoscaling, multi-AZ placement, billing model and STS auto mode fall back to the rosa CLI defaults when omitted.
+
+The inputs are first checked locally, as with the validate_cluster_config tool. Set dry_run to have OCM validate the cluster and return the submitted spec without creating anything. Set network_verification_id to require a passing verify_network run for the subnets.
 
 Use the workflow from the get_rosa_hcp_prerequisites_guide tool or prompt to guide a user through completing the necessary pre-requisite steps and collecting the required configuration values.`),
-			mcp.WithString("cluster_name", mcp.Description("Name for the cluster"), mcp.Required()),
-			mcp.WithString("aws_account_id", mcp.Description("AWS account ID"), mcp.Required()),
-			mcp.WithString("billing_account_id", mcp.Description("AWS billing account ID"), mcp.Required()),
-			mcp.WithString("role_arn", mcp.Description("IAM installer role ARN"), mcp.Required()),
-			mcp.WithString("operator_role_prefix", mcp.Description("Operator role prefix"), mcp.Required()),
-			mcp.WithString("oidc_config_id", mcp.Description("OIDC configuration ID"), mcp.Required()),
-			mcp.WithString("support_role_arn", mcp.Description("IAM support role ARN"), mcp.Required()),
-			mcp.WithString("worker_role_arn", mcp.Description("IAM worker role ARN"), mcp.Required()),
-			mcp.WithString("rosa_creator_arn", mcp.Description("ROSA creator ARN"), mcp.Required()),
-			mcp.WithArray("subnet_ids", mcp.Description("Array of subnet IDs"), mcp.Required()),
-			mcp.WithArray("availability_zones", mcp.Description("Array of availability zones for the subnets"), mcp.Required()),
-			mcp.WithString("region", mcp.Description("AWS region"), mcp.DefaultString("us-east-1")),
-			mcp.WithBoolean("multi_arch_enabled", mcp.Description("Enable multi-architecture support"), mcp.DefaultBool(false)),
+			mcp.WithBoolean("dry_run", mcp.Description("Validate the cluster with OCM and return the submitted cluster spec without creating it (default: false)")),
+			mcp.WithString("network_verification_id", mcp.Description("ID of a verify_network run that covered every subnet of the cluster. Creation is refused unless each subnet's latest verification passed")),
 			mcp.WithReadOnlyHintAnnotation(false),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
-		), Handler: s.handleCreateROSAHCPCluster},
+		)...), Handler: s.handleCreateROSAHCPCluster},
+
+		{Tool: mcp.NewTool("validate_cluster_config", clusterSpecToolOptions(
+			mcp.WithDescription(`Check the inputs of a ROSA HCP cluster locally, without contacting OCM or AWS.
+
+Takes the same parameters as create_rosa_hcp_cluster and reports every problem found: cluster name rules, AWS account ID and IAM role ARN formats, roles from another AWS account, operator role prefix rules, subnet ID format, subnet and availability zone counts, availability zones outside the region, and the optional version, scaling, proxy, encryption, network and tag settings. The same checks run at the start of create_rosa_hcp_cluster.`),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(false),
+		)...), Handler: s.handleValidateClusterConfig},
 
 		{Tool: mcp.NewTool("get_rosa_hcp_prerequisites_guide",
 			mcp.WithDescription(`Get the complete workflow prompt for ROSA HCP cluster installation prerequisites and setup.
@@ -66,6 +87,128 @@ Use this workflow to guide a user through the complete setup process for creatin
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.handleGetROSAHCPPrerequisitesGuide},
 
+		{Tool: mcp.NewTool("list_versions",
+			mcp.WithDescription("List the OpenShift versions ROSA HCP clusters can be installed with, newest first, with the default version and end-of-life dates. Use one of these versions for the version parameter of create_rosa_hcp_cluster."),
+			mcp.WithString("channel_group", mcp.Description("Channel group to list versions from"), mcp.Enum(ocm.ValidChannelGroups...), mcp.DefaultString(ocm.DefaultChannelGroup)),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListVersions},
+
+		{Tool: mcp.NewTool("list_regions",
+			mcp.WithDescription("List the AWS regions available for ROSA clusters, by default only those that support ROSA HCP. Use one of these regions for the region parameter of create_rosa_hcp_cluster."),
+			mcp.WithBoolean("hcp_only", mcp.Description("Only list regions that support ROSA HCP clusters"), mcp.DefaultBool(true)),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListRegions},
+
+		{Tool: mcp.NewTool("list_machine_types",
+			mcp.WithDescription("List the AWS instance types available for compute nodes, for compute_machine_type of create_rosa_hcp_cluster and instance_type of create_node_pool. arm64 instance types require a cluster created with multi_arch_enabled."),
+			mcp.WithString("architecture", mcp.Description("CPU architecture: amd64 (x86_64) or arm64"), mcp.Enum(ocm.ValidArchitectures...)),
+			mcp.WithString("category", mcp.Description("Instance type category"), mcp.Enum(ocm.ValidMachineTypeCategories...)),
+			mcp.WithNumber("min_cpu", mcp.Description("Minimum number of vCPUs")),
+			mcp.WithNumber("max_cpu", mcp.Description("Maximum number of vCPUs")),
+			mcp.WithNumber("min_memory_gib", mcp.Description("Minimum memory in GiB")),
+			mcp.WithNumber("max_memory_gib", mcp.Description("Maximum memory in GiB")),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListMachineTypes},
+
+		{Tool: mcp.NewTool("list_vpcs",
+			mcp.WithDescription(`List the VPCs and subnets of an AWS region, using the installer role through OCM, and recommend subnet_ids and availability_zones for create_rosa_hcp_cluster.
+
+Each subnet is shown with its availability zone, whether it is public or private, and the load balancer role tag it needs. Public clusters need a private subnet in each availability zone and a public subnet for their load balancers; private clusters only use private subnets.`),
+			mcp.WithString("aws_account_id", mcp.Description("AWS account ID that owns the VPCs"), mcp.Required()),
+			mcp.WithString("role_arn", mcp.Description("Installer role ARN OCM assumes to read the VPCs"), mcp.Required()),
+			mcp.WithString("region", mcp.Description("AWS region"), mcp.Required()),
+			mcp.WithString("vpc_id", mcp.Description("Only show this VPC")),
+			mcp.WithBoolean("private", mcp.Description("Recommend subnets for a private cluster"), mcp.DefaultBool(false)),
+			mcp.WithNumber("zone_count", mcp.Description("Number of availability zones to recommend subnets in, 1 to 3. Defaults to every suitable zone, up to 3")),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListVPCs},
+
+		{Tool: mcp.NewTool("verify_network",
+			mcp.WithDescription(`Verify that subnets reach the egress endpoints a ROSA HCP cluster needs, before creating the cluster.
+
+Starts an OCM network verification, which runs in the AWS account through the installer role, and by default waits until it finishes, sending progress notifications. Reports pass or fail per subnet with the failing egress targets. The returned verification ID can be checked later with get_network_verification and passed as network_verification_id to create_rosa_hcp_cluster.`),
+			mcp.WithString("role_arn", mcp.Description("Installer role ARN OCM assumes to run the verification"), mcp.Required()),
+			mcp.WithString("region", mcp.Description("AWS region of the subnets"), mcp.Required()),
+			mcp.WithArray("subnet_ids", mcp.Description("Subnet IDs to verify"), mcp.Required()),
+			mcp.WithBoolean("wait", mcp.Description("Wait until the verification finishes"), mcp.DefaultBool(true)),
+			mcp.WithNumber("timeout_seconds", mcp.Description("Maximum time to wait"), mcp.DefaultNumber(600)),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleVerifyNetwork},
+
+		{Tool: mcp.NewTool("get_network_verification",
+			mcp.WithDescription("Get the per-subnet results of a network verification started with verify_network, optionally waiting until it finishes."),
+			mcp.WithString("verification_id", mcp.Description("Verification ID returned by verify_network"), mcp.Required()),
+			mcp.WithBoolean("wait", mcp.Description("Wait until the verification finishes"), mcp.DefaultBool(false)),
+			mcp.WithNumber("timeout_seconds", mcp.Description("Maximum time to wait"), mcp.DefaultNumber(600)),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleGetNetworkVerification},
+
+		{Tool: mcp.NewTool("list_oidc_configs",
+			mcp.WithDescription("List the OIDC configurations of the organization and the clusters that use each one. Reusable configurations can be passed as oidc_config_id to create_rosa_hcp_cluster."),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListOIDCConfigs},
+
+		{Tool: mcp.NewTool("create_oidc_config",
+			mcp.WithDescription("Create a managed, reusable OIDC configuration whose issuer is hosted by Red Hat. The response includes the oidc_config_id for create_rosa_hcp_cluster and the command that registers the OIDC provider in the AWS account."),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleCreateOIDCConfig},
+
+		{Tool: mcp.NewTool("delete_oidc_config",
+			mcp.WithDescription(`Delete an OIDC configuration that no cluster uses.
+
+Deletion is a two-phase operation. Call this tool without confirmation_token to receive a summary and a short-lived confirmation token. Only after the user explicitly confirms, call it again with the confirmation_token to delete the OIDC configuration.`),
+			mcp.WithString("oidc_config_id", mcp.Description("OIDC configuration ID"), mcp.Required()),
+			mcp.WithString("confirmation_token", mcp.Description("Confirmation token returned by a previous delete_oidc_config call for this OIDC configuration")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleDeleteOIDCConfig},
+
+		{Tool: mcp.NewTool("validate_account_roles",
+			mcp.WithDescription(`Check the account role ARNs of a ROSA HCP cluster before calling create_rosa_hcp_cluster.
+
+Reports, per role, ARN format problems, roles from another AWS account, ROSA classic roles, roles passed for the wrong parameter and roles that do not follow the <prefix>-HCP-ROSA-<Type>-Role naming convention, with the managed policy OCM expects each role to have attached. When version is set, it must be available for ROSA HCP.`),
+			mcp.WithString("aws_account_id", mcp.Description("AWS account ID the roles belong to"), mcp.Required()),
+			mcp.WithString("role_arn", mcp.Description("Installer role ARN"), mcp.Required()),
+			mcp.WithString("support_role_arn", mcp.Description("Support role ARN"), mcp.Required()),
+			mcp.WithString("worker_role_arn", mcp.Description("Worker role ARN"), mcp.Required()),
+			mcp.WithString("version", mcp.Description("OpenShift version of the cluster, e.g. 4.16.3")),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleValidateAccountRoles},
+
+		{Tool: mcp.NewTool("get_operator_roles",
+			mcp.WithDescription(`Get the IAM operator roles a ROSA HCP cluster needs, built from the operator credential requests and managed policies published by OCM.
+
+For each role the response includes the role name and ARN, the trust policy that lets the operator's service accounts assume it through the cluster's OIDC provider, and the permission policy ARNs to attach. The roles can also be returned as aws iam commands or as a Terraform snippet. Pass the same operator_role_prefix to create_rosa_hcp_cluster.`),
+			mcp.WithString("operator_role_prefix", mcp.Description("Prefix of the operator role names"), mcp.Required()),
+			mcp.WithString("aws_account_id", mcp.Description("AWS account ID the roles are created in"), mcp.Required()),
+			mcp.WithString("oidc_config_id", mcp.Description("OIDC configuration ID whose issuer the roles trust. Required unless oidc_issuer_url is set")),
+			mcp.WithString("oidc_issuer_url", mcp.Description("OIDC issuer URL the roles trust, for example https://oidc.os1.devshift.org/abc123. Required unless oidc_config_id is set")),
+			mcp.WithString("version", mcp.Description("OpenShift version of the cluster; only the operators of this version are included")),
+			mcp.WithString("format", mcp.Description("Output format: summary, aws_cli (aws iam commands) or terraform"), mcp.Enum("summary", "aws_cli", "terraform"), mcp.DefaultString("summary")),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleGetOperatorRoles},
+
 		{Tool: mcp.NewTool("setup_htpasswd_identity_provider",
 			mcp.WithDescription(`Setup an HTPasswd identity provider for a ROSA HCP cluster.
 
@@ -75,10 +218,366 @@ HTPasswd is a common identity provider for development and testing environments.
 			mcp.WithString("mapping_method", mcp.Description("User mapping method - options: add, claim, generate, lookup"), mcp.DefaultString("claim")),
 			mcp.WithArray("users", mcp.Description("List of username:password pairs [\"user1:password1\", \"user2:password2\"]"), mcp.Required()),
 			mcp.WithBoolean("overwrite_existing", mcp.Description("Whether to overwrite if IDP with same name exists"), mcp.DefaultBool(false)),
+			mcp.WithString("overwrite_mode",
+				mcp.Description("How an existing IDP is overwritten: replace_users makes its users exactly the given list, merge adds new users and resets the passwords of existing ones, recreate deletes and recreates the IDP (required to change the mapping method; users must include every existing user with a password, which is also used to restore the original if recreation fails, so remove users with replace_users first)"),
+				mcp.Enum(string(ocm.HTPasswdOverwriteReplaceUsers), string(ocm.HTPasswdOverwriteMerge), string(ocm.HTPasswdOverwriteRecreate)),
+				mcp.DefaultString(string(ocm.HTPasswdOverwriteReplaceUsers))),
 			mcp.WithReadOnlyHintAnnotation(false),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.handleSetupHTPasswdIdentityProvider},
+
+		{Tool: mcp.NewTool("setup_github_identity_provider",
+			mcp.WithDescription(`Setup a GitHub or GitHub Enterprise identity provider for a cluster.
+
+Create an OAuth app in GitHub first; the response contains the callback URL to register with it. Access must be restricted to either organizations or teams.`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Identity provider name"), mcp.DefaultString("github")),
+			mcp.WithString("mapping_method", mcp.Description("User mapping method - options: add, claim, generate, lookup"), mcp.DefaultString("claim")),
+			mcp.WithString("client_id", mcp.Description("Client ID of the GitHub OAuth app"), mcp.Required()),
+			mcp.WithString("client_secret", mcp.Description("Client secret of the GitHub OAuth app"), mcp.Required()),
+			mcp.WithArray("organizations", mcp.Description("GitHub organizations whose members can log in (mutually exclusive with teams)")),
+			mcp.WithArray("teams", mcp.Description("GitHub teams whose members can log in, in the format org/team (mutually exclusive with organizations)")),
+			mcp.WithString("hostname", mcp.Description("GitHub Enterprise host name (omit for github.com)")),
+			mcp.WithString("ca", mcp.Description("PEM encoded CA bundle for a GitHub Enterprise host")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleSetupGithubIdentityProvider},
+
+		{Tool: mcp.NewTool("setup_gitlab_identity_provider",
+			mcp.WithDescription(`Setup a GitLab identity provider for a cluster.
+
+Create an OAuth application in GitLab first; the response contains the callback URL to register with it.`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Identity provider name"), mcp.DefaultString("gitlab")),
+			mcp.WithString("mapping_method", mcp.Description("User mapping method - options: add, claim, generate, lookup"), mcp.DefaultString("claim")),
+			mcp.WithString("url", mcp.Description("https URL of the GitLab instance"), mcp.DefaultString("https://gitlab.com")),
+			mcp.WithString("client_id", mcp.Description("Application ID of the GitLab OAuth application"), mcp.Required()),
+			mcp.WithString("client_secret", mcp.Description("Secret of the GitLab OAuth application"), mcp.Required()),
+			mcp.WithString("ca", mcp.Description("PEM encoded CA bundle for a self-hosted GitLab instance")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleSetupGitlabIdentityProvider},
+
+		{Tool: mcp.NewTool("setup_google_identity_provider",
+			mcp.WithDescription(`Setup a Google identity provider for a cluster.
+
+Create an OAuth client in Google Cloud first; the response contains the callback URL to register as an authorized redirect URI. A hosted domain is required unless the mapping method is lookup.`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Identity provider name"), mcp.DefaultString("google")),
+			mcp.WithString("mapping_method", mcp.Description("User mapping method - options: add, claim, generate, lookup"), mcp.DefaultString("claim")),
+			mcp.WithString("client_id", mcp.Description("Google OAuth client ID"), mcp.Required()),
+			mcp.WithString("client_secret", mcp.Description("Google OAuth client secret"), mcp.Required()),
+			mcp.WithString("hosted_domain", mcp.Description("Google Workspace domain whose users can log in, e.g. example.com")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleSetupGoogleIdentityProvider},
+
+		{Tool: mcp.NewTool("setup_openid_identity_provider",
+			mcp.WithDescription(`Setup an OpenID Connect identity provider for a cluster.
+
+Register a client with the OpenID provider first; the response contains the callback URL to register as its redirect URI. Claims default to the standard email, name and preferred_username claims.`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Identity provider name"), mcp.DefaultString("openid")),
+			mcp.WithString("mapping_method", mcp.Description("User mapping method - options: add, claim, generate, lookup"), mcp.DefaultString("claim")),
+			mcp.WithString("issuer_url", mcp.Description("https issuer URL of the OpenID provider"), mcp.Required()),
+			mcp.WithString("client_id", mcp.Description("OpenID client ID"), mcp.Required()),
+			mcp.WithString("client_secret", mcp.Description("OpenID client secret"), mcp.Required()),
+			mcp.WithString("ca", mcp.Description("PEM encoded CA bundle used to verify the issuer")),
+			mcp.WithArray("extra_scopes", mcp.Description("Additional scopes to request, e.g. [\"groups\"]")),
+			mcp.WithArray("email_claims", mcp.Description("Claims used as the email address")),
+			mcp.WithArray("name_claims", mcp.Description("Claims used as the display name")),
+			mcp.WithArray("username_claims", mcp.Description("Claims used as the preferred username")),
+			mcp.WithArray("groups_claims", mcp.Description("Claims used to synchronize groups")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleSetupOpenIDIdentityProvider},
+
+		{Tool: mcp.NewTool("setup_ldap_identity_provider",
+			mcp.WithDescription(`Setup an LDAP identity provider for a cluster.
+
+The URL uses the RFC 2255 format ldap://host:port/basedn?attribute?scope?filter. Attributes default to dn (ID), uid (username), cn (name) and mail (email).`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Identity provider name"), mcp.DefaultString("ldap")),
+			mcp.WithString("mapping_method", mcp.Description("User mapping method - options: add, claim, generate, lookup"), mcp.DefaultString("claim")),
+			mcp.WithString("url", mcp.Description("LDAP search URL, e.g. ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid"), mcp.Required()),
+			mcp.WithString("bind_dn", mcp.Description("DN to bind with during the search phase")),
+			mcp.WithString("bind_password", mcp.Description("Password to bind with during the search phase")),
+			mcp.WithString("ca", mcp.Description("PEM encoded CA bundle used to verify the LDAP server")),
+			mcp.WithBoolean("insecure", mcp.Description("Connect without TLS (only valid for ldap:// URLs)"), mcp.DefaultBool(false)),
+			mcp.WithArray("id_attributes", mcp.Description("Attributes used as the user identity")),
+			mcp.WithArray("username_attributes", mcp.Description("Attributes used as the preferred username")),
+			mcp.WithArray("name_attributes", mcp.Description("Attributes used as the display name")),
+			mcp.WithArray("email_attributes", mcp.Description("Attributes used as the email address")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleSetupLDAPIdentityProvider},
+
+		{Tool: mcp.NewTool("delete_cluster",
+			mcp.WithDescription(`Delete a cluster. This permanently destroys the cluster and cannot be undone.
+
+Deletion is a two-phase operation. Call this tool with only cluster_id to receive a summary of what will be deleted and a short-lived confirmation token. Show the summary to the user and, only after they explicitly confirm, call this tool again with the same cluster_id and the confirmation_token to delete the cluster.`),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithString("confirmation_token", mcp.Description("Confirmation token returned by a previous delete_cluster call for this cluster")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleDeleteCluster},
+
+		{Tool: mcp.NewTool("list_node_pools",
+			mcp.WithDescription("List the node pools (machine pools) of a ROSA HCP cluster"),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListNodePools},
+
+		{Tool: mcp.NewTool("create_node_pool",
+			mcp.WithDescription(`Create a node pool (machine pool) on a ROSA HCP cluster.
+
+Provide either replicas for a fixed size node pool, or min_replicas and max_replicas to enable autoscaling.`),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Node pool name (lower-case alphanumeric and '-', at most 15 characters)"), mcp.Required()),
+			mcp.WithString("subnet_id", mcp.Description("Subnet ID the node pool instances are created in"), mcp.Required()),
+			mcp.WithString("instance_type", mcp.Description("AWS instance type for the nodes"), mcp.DefaultString("m5.xlarge")),
+			mcp.WithNumber("replicas", mcp.Description("Fixed number of nodes (mutually exclusive with min_replicas/max_replicas)")),
+			mcp.WithNumber("min_replicas", mcp.Description("Minimum number of nodes when autoscaling")),
+			mcp.WithNumber("max_replicas", mcp.Description("Maximum number of nodes when autoscaling")),
+			mcp.WithString("version", mcp.Description("OpenShift version for the node pool (defaults to the control plane version)")),
+			mcp.WithObject("labels", mcp.Description("Kubernetes labels applied to the nodes as key/value pairs")),
+			mcp.WithArray("taints", mcp.Description("Taints applied to the nodes in the format key=value:Effect (Effect is NoSchedule, PreferNoSchedule or NoExecute)")),
+			mcp.WithBoolean("auto_repair", mcp.Description("Automatically replace unhealthy nodes"), mcp.DefaultBool(true)),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleCreateNodePool},
+
+		{Tool: mcp.NewTool("scale_node_pool",
+			mcp.WithDescription(`Scale a node pool of a ROSA HCP cluster.
+
+Provide either replicas to set a fixed size (disabling autoscaling), or min_replicas and max_replicas to enable or adjust autoscaling.`),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithString("node_pool_id", mcp.Description("Node pool identifier"), mcp.Required()),
+			mcp.WithNumber("replicas", mcp.Description("Fixed number of nodes (mutually exclusive with min_replicas/max_replicas)")),
+			mcp.WithNumber("min_replicas", mcp.Description("Minimum number of nodes when autoscaling")),
+			mcp.WithNumber("max_replicas", mcp.Description("Maximum number of nodes when autoscaling")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleScaleNodePool},
+
+		{Tool: mcp.NewTool("update_node_pool",
+			mcp.WithDescription(`Update the labels, taints or auto-repair setting of a node pool.
+
+Only the parameters provided are changed. Labels and taints replace the existing values; pass an empty object or array to clear them.`),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithString("node_pool_id", mcp.Description("Node pool identifier"), mcp.Required()),
+			mcp.WithObject("labels", mcp.Description("Kubernetes labels applied to the nodes as key/value pairs")),
+			mcp.WithArray("taints", mcp.Description("Taints applied to the nodes in the format key=value:Effect (Effect is NoSchedule, PreferNoSchedule or NoExecute)")),
+			mcp.WithBoolean("auto_repair", mcp.Description("Automatically replace unhealthy nodes")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleUpdateNodePool},
+
+		{Tool: mcp.NewTool("delete_node_pool",
+			mcp.WithDescription(`Delete a node pool from a ROSA HCP cluster. Workloads on its nodes are evicted.
+
+Deletion is a two-phase operation. Call this tool without confirmation_token to receive a summary and a short-lived confirmation token. Only after the user explicitly confirms, call it again with the confirmation_token to delete the node pool.`),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithString("node_pool_id", mcp.Description("Node pool identifier"), mcp.Required()),
+			mcp.WithString("confirmation_token", mcp.Description("Confirmation token returned by a previous delete_node_pool call for this node pool")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleDeleteNodePool},
+
+		{Tool: mcp.NewTool("list_upgrade_versions",
+			mcp.WithDescription("List the versions the control plane and each node pool of a ROSA HCP cluster can be upgraded to"),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListUpgradeVersions},
+
+		{Tool: mcp.NewTool("schedule_upgrade",
+			mcp.WithDescription(`Schedule an upgrade of the control plane, or of a node pool when node_pool_id is given.
+
+schedule_type options:
+- now: upgrade to version as soon as possible (about 10 minutes from now)
+- scheduled: upgrade to version at next_run
+- recurring: upgrade automatically to the latest patch version on the cron schedule
+
+Before a control plane minor version upgrade is scheduled, any unacknowledged version gates are reported together with a short-lived confirmation token and nothing is scheduled. Review the gates with the user and, only if they accept them, call again with the same arguments and the confirmation_token to acknowledge exactly those gates and schedule the upgrade. Upgrade the control plane before its node pools.`),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithString("node_pool_id", mcp.Description("Node pool to upgrade (omit to upgrade the control plane)")),
+			mcp.WithString("version", mcp.Description("Target OpenShift version (required for now and scheduled upgrades)")),
+			mcp.WithString("schedule_type", mcp.Description("When to upgrade"), mcp.Enum("now", "scheduled", "recurring"), mcp.DefaultString("now")),
+			mcp.WithString("next_run", mcp.Description("Upgrade start time in RFC3339 format, e.g. 2025-01-31T22:00:00Z (scheduled only)")),
+			mcp.WithString("schedule", mcp.Description("Cron expression in UTC, e.g. '0 2 * * 6' (recurring only)")),
+			mcp.WithString("confirmation_token", mcp.Description("Confirmation token returned with a previous version gate report for this cluster and version")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleScheduleUpgrade},
+
+		{Tool: mcp.NewTool("list_upgrade_policies",
+			mcp.WithDescription("List the scheduled upgrade policies of a cluster's control plane and node pools"),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithString("node_pool_id", mcp.Description("Only list the upgrade policies of this node pool")),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListUpgradePolicies},
+
+		{Tool: mcp.NewTool("cancel_upgrade",
+			mcp.WithDescription("Cancel a scheduled upgrade policy of the control plane, or of a node pool when node_pool_id is given"),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithString("policy_id", mcp.Description("Upgrade policy identifier from list_upgrade_policies"), mcp.Required()),
+			mcp.WithString("node_pool_id", mcp.Description("Node pool the upgrade policy belongs to (omit for control plane policies)")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleCancelUpgrade},
+
+		{Tool: mcp.NewTool("get_install_logs",
+			mcp.WithDescription(`Get the installation logs of a cluster.
+
+Use tail to retrieve only the most recent lines, or offset to skip lines already seen. With follow=true the tool keeps streaming new lines as MCP progress notifications until the cluster leaves the installing state or timeout_seconds elapses.`),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithNumber("offset", mcp.Description("Number of log lines to skip from the start of the log")),
+			mcp.WithNumber("tail", mcp.Description("Only return the last N lines of the log (cannot be combined with offset)")),
+			mcp.WithBoolean("follow", mcp.Description("Keep streaming new log lines until installation finishes"), mcp.DefaultBool(false)),
+			mcp.WithNumber("timeout_seconds", mcp.Description("Maximum time to follow the log"), mcp.DefaultNumber(1800)),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleGetInstallLogs},
+
+		{Tool: mcp.NewTool("get_uninstall_logs",
+			mcp.WithDescription(`Get the uninstallation logs of a cluster.
+
+Use tail to retrieve only the most recent lines, or offset to skip lines already seen. With follow=true the tool keeps streaming new lines as MCP progress notifications until the cluster leaves the uninstalling state or timeout_seconds elapses.`),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithNumber("offset", mcp.Description("Number of log lines to skip from the start of the log")),
+			mcp.WithNumber("tail", mcp.Description("Only return the last N lines of the log (cannot be combined with offset)")),
+			mcp.WithBoolean("follow", mcp.Description("Keep streaming new log lines until uninstallation finishes"), mcp.DefaultBool(false)),
+			mcp.WithNumber("timeout_seconds", mcp.Description("Maximum time to follow the log"), mcp.DefaultNumber(1800)),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleGetUninstallLogs},
+
+		{Tool: mcp.NewTool("wait_for_cluster_state",
+			mcp.WithDescription(`Wait until a cluster reaches a target state instead of repeatedly calling get_cluster.
+
+Blocks until the cluster reaches target_state, enters the error state, or timeout_seconds elapses. OCM is polled with backoff and the current state is sent as MCP progress notifications. Use target_state=deleted to wait for an uninstalling cluster to be removed.`),
+			mcp.WithString("cluster_id", mcp.Description("Unique cluster identifier"), mcp.Required()),
+			mcp.WithString("target_state", mcp.Description("Cluster state to wait for"), mcp.Enum("ready", "installing", "updating", "hibernating", "uninstalling", "deleted"), mcp.DefaultString("ready")),
+			mcp.WithNumber("timeout_seconds", mcp.Description("Maximum time to wait"), mcp.DefaultNumber(3600)),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleWaitForClusterState},
+
+		{Tool: mcp.NewTool("create_cluster_admin",
+			mcp.WithDescription(`Create a temporary cluster-admin user for a ROSA HCP cluster, like 'rosa create admin'.
+
+Creates the reserved 'cluster-admin' HTPasswd identity provider with a generated password and adds the user to the cluster-admins group. The password is returned only once and cannot be retrieved later. Remove the user with delete_cluster_admin once a permanent identity provider is configured.`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleCreateClusterAdmin},
+
+		{Tool: mcp.NewTool("delete_cluster_admin",
+			mcp.WithDescription("Revoke the temporary cluster-admin user: remove it from the cluster-admins group and delete the reserved 'cluster-admin' identity provider"),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleDeleteClusterAdmin},
+
+		{Tool: mcp.NewTool("list_identity_providers",
+			mcp.WithDescription("List the identity providers configured on a cluster, including type, mapping method and HTPasswd usernames"),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListIdentityProviders},
+
+		{Tool: mcp.NewTool("describe_identity_provider",
+			mcp.WithDescription("Show the configuration of a single identity provider. Secrets and password hashes are never shown."),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("idp", mcp.Description("Identity provider name or ID"), mcp.Required()),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleDescribeIdentityProvider},
+
+		{Tool: mcp.NewTool("delete_identity_provider",
+			mcp.WithDescription(`Delete an identity provider from a cluster. Users of this provider can no longer log in.
+
+Deletion is a two-phase operation. Call this tool without confirmation_token to receive a summary and a short-lived confirmation token. Only after the user explicitly confirms, call it again with the confirmation_token to delete the identity provider. Use delete_cluster_admin to remove the reserved cluster-admin provider.`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("idp", mcp.Description("Identity provider name or ID"), mcp.Required()),
+			mcp.WithString("confirmation_token", mcp.Description("Confirmation token returned by a previous delete_identity_provider call for this identity provider")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleDeleteIdentityProvider},
+
+		{Tool: mcp.NewTool("list_htpasswd_users",
+			mcp.WithDescription("List the users of an HTPasswd identity provider. Password hashes are never shown."),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("idp", mcp.Description("HTPasswd identity provider name or ID"), mcp.Required()),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleListHTPasswdUsers},
+
+		{Tool: mcp.NewTool("add_htpasswd_users",
+			mcp.WithDescription(`Add users to an existing HTPasswd identity provider without recreating it.
+
+All users are validated before any are added. Existing users are not modified; use reset_htpasswd_user_password to change a password.`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("idp", mcp.Description("HTPasswd identity provider name or ID"), mcp.Required()),
+			mcp.WithArray("users", mcp.Description("List of username:password pairs [\"user1:password1\", \"user2:password2\"]"), mcp.Required()),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleAddHTPasswdUsers},
+
+		{Tool: mcp.NewTool("remove_htpasswd_user",
+			mcp.WithDescription(`Remove a user from an HTPasswd identity provider. The user can no longer log in.
+
+Removal is a two-phase operation. Call this tool without confirmation_token to receive a summary and a short-lived confirmation token. Only after the user explicitly confirms, call it again with the confirmation_token to remove the user.`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("idp", mcp.Description("HTPasswd identity provider name or ID"), mcp.Required()),
+			mcp.WithString("username", mcp.Description("Username to remove"), mcp.Required()),
+			mcp.WithString("confirmation_token", mcp.Description("Confirmation token returned by a previous remove_htpasswd_user call for this user")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleRemoveHTPasswdUser},
+
+		{Tool: mcp.NewTool("reset_htpasswd_user_password",
+			mcp.WithDescription(`Reset the password of a user in an HTPasswd identity provider.
+
+If password is omitted a strong password is generated and returned once.`),
+			mcp.WithString("cluster_id", mcp.Description("Target cluster identifier"), mcp.Required()),
+			mcp.WithString("idp", mcp.Description("HTPasswd identity provider name or ID"), mcp.Required()),
+			mcp.WithString("username", mcp.Description("Username whose password is reset"), mcp.Required()),
+			mcp.WithString("password", mcp.Description("New password (generated when omitted)")),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.handleResetHTPasswdUserPassword},
 	}
 }
 
@@ -118,12 +617,47 @@ func (s *Server) handleWhoami(ctx context.Context, ctr mcp.CallToolRequest) (*mc
 func (s *Server) handleGetClusters(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := ctr.GetArguments()
 
-	state, ok := args["state"].(string)
-	if !ok || state == "" {
-		return NewTextResult("", errors.New("missing required argument: state")), nil
+	search := &ocm.ClusterSearch{
+		NameOrID: mcp.ParseString(ctr, "search", ""),
+		States:   parseStringArray(args, "states"),
+		Region:   mcp.ParseString(ctr, "region", ""),
+		Version:  mcp.ParseString(ctr, "version", ""),
+		Product:  mcp.ParseString(ctr, "product", ""),
+		Creator:  mcp.ParseString(ctr, "creator", ""),
+		SortBy:   mcp.ParseString(ctr, "sort_by", ""),
+	}
+	var err error
+	if search.Hypershift, err = parseOptionalBool(args, "hypershift"); err != nil {
+		return NewTextResult("", err), nil
+	}
+	if search.CreatedAfter, err = parseOptionalTime(args, "created_after", false); err != nil {
+		return NewTextResult("", err), nil
+	}
+	if search.CreatedBefore, err = parseOptionalTime(args, "created_before", true); err != nil {
+		return NewTextResult("", err), nil
+	}
+	switch sortOrder := mcp.ParseString(ctr, "sort_order", "asc"); sortOrder {
+	case "asc":
+	case "desc":
+		search.Descending = true
+	default:
+		return NewTextResult("", fmt.Errorf("invalid sort_order '%s': must be asc or desc", sortOrder)), nil
+	}
+	if err := search.Validate(); err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	fields := parseStringArray(args, "fields")
+	if len(fields) == 0 {
+		fields = defaultClusterFields
+	}
+	for _, field := range fields {
+		if !slices.Contains(validClusterFields, field) {
+			return NewTextResult("", fmt.Errorf("invalid field '%s': must be one of %s", field, strings.Join(validClusterFields, ", "))), nil
+		}
 	}
 
-	s.logToolCall("get_clusters", map[string]interface{}{"state": state})
+	s.logToolCall("get_clusters", args)
 
 	// Get authenticated OCM client
 	client, err := s.getAuthenticatedOCMClient(ctx)
@@ -132,14 +666,13 @@ func (s *Server) handleGetClusters(ctx context.Context, ctr mcp.CallToolRequest)
 	}
 	defer client.Close()
 
-	// Call OCM client to get clusters with state filter
-	clusters, err := client.GetClusters(state)
+	clusters, err := client.GetClusters(search)
 	if errorResult := handleOCMError(err, "failed to get clusters"); errorResult != nil {
 		return errorResult, nil
 	}
 
 	// Format response using MCP layer formatter
-	formattedResponse := formatClustersResponse(clusters)
+	formattedResponse := formatClustersResponse(clusters, fields)
 	return NewTextResult(formattedResponse, nil), nil
 }
 
@@ -172,125 +705,198 @@ func (s *Server) handleGetCluster(ctx context.Context, ctr mcp.CallToolRequest)
 	return NewTextResult(formattedResponse, nil), nil
 }
 
-// handleCreateROSAHCPCluster handles the create_rosa_hcp_cluster tool
-func (s *Server) handleCreateROSAHCPCluster(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// clusterSpecToolOptions returns the parameters describing a ROSA HCP cluster, shared by the tools that
+// create and validate clusters, followed by the given tool options
+func clusterSpecToolOptions(options ...mcp.ToolOption) []mcp.ToolOption {
+	return append([]mcp.ToolOption{
+		mcp.WithString("cluster_name", mcp.Description("Name for the cluster"), mcp.Required()),
+		mcp.WithString("aws_account_id", mcp.Description("AWS account ID"), mcp.Required()),
+		mcp.WithString("billing_account_id", mcp.Description("AWS billing account ID"), mcp.Required()),
+		mcp.WithString("role_arn", mcp.Description("IAM installer role ARN"), mcp.Required()),
+		mcp.WithString("operator_role_prefix", mcp.Description("Operator role prefix"), mcp.Required()),
+		mcp.WithString("oidc_config_id", mcp.Description("OIDC configuration ID"), mcp.Required()),
+		mcp.WithString("support_role_arn", mcp.Description("IAM support role ARN"), mcp.Required()),
+		mcp.WithString("worker_role_arn", mcp.Description("IAM worker role ARN"), mcp.Required()),
+		mcp.WithString("rosa_creator_arn", mcp.Description("ROSA creator ARN"), mcp.Required()),
+		mcp.WithArray("subnet_ids", mcp.Description("Array of subnet IDs"), mcp.Required()),
+		mcp.WithArray("availability_zones", mcp.Description("Array of availability zones for the subnets"), mcp.Required()),
+		mcp.WithString("region", mcp.Description("AWS region"), mcp.DefaultString("us-east-1")),
+		mcp.WithBoolean("multi_arch_enabled", mcp.Description("Enable multi-architecture support"), mcp.DefaultBool(false)),
+		mcp.WithString("version", mcp.Description("OpenShift version, e.g. 4.16.3 (defaults to the current default version)")),
+		mcp.WithString("channel_group", mcp.Description("Channel group of version, as listed by list_versions (default: stable)"), mcp.Enum(ocm.ValidChannelGroups...)),
+		mcp.WithString("compute_machine_type", mcp.Description("AWS instance type of the compute nodes"), mcp.DefaultString(ocm.DefaultComputeMachineType)),
+		mcp.WithNumber("replicas", mcp.Description(fmt.Sprintf("Fixed number of compute nodes, at least 2 (defaults to %d; mutually exclusive with min_replicas/max_replicas)", ocm.DefaultComputeReplicas))),
+		mcp.WithNumber("min_replicas", mcp.Description("Minimum number of compute nodes when autoscaling")),
+		mcp.WithNumber("max_replicas", mcp.Description("Maximum number of compute nodes when autoscaling")),
+		mcp.WithBoolean("multi_az", mcp.Description("Spread compute nodes across the availability zones of the subnets"), mcp.DefaultBool(true)),
+		mcp.WithString("billing_model", mcp.Description("Billing model"), mcp.Enum("marketplace-aws", "standard"), mcp.DefaultString(ocm.DefaultBillingModel)),
+		mcp.WithBoolean("sts_auto_mode", mcp.Description("Let OCM create the operator roles automatically"), mcp.DefaultBool(true)),
+		mcp.WithBoolean("private", mcp.Description("Make the API server reachable only from inside the VPC through AWS PrivateLink. All subnets must be private."), mcp.DefaultBool(false)),
+		mcp.WithString("http_proxy", mcp.Description("Cluster-wide proxy for HTTP connections, e.g. http://proxy.example.com:3128")),
+		mcp.WithString("https_proxy", mcp.Description("Cluster-wide proxy for HTTPS connections")),
+		mcp.WithArray("no_proxy", mcp.Description("Domains, IP addresses or CIDRs that bypass the proxy")),
+		mcp.WithString("additional_trust_bundle", mcp.Description("PEM encoded CA bundle trusted by the cluster, e.g. for a TLS-inspecting proxy")),
+		mcp.WithString("kms_key_arn", mcp.Description("ARN of a customer-managed KMS key, in the cluster region, used to encrypt node EBS volumes")),
+		mcp.WithString("etcd_encryption_kms_key_arn", mcp.Description("ARN of a customer-managed KMS key, in the cluster region, used to encrypt etcd; enables etcd encryption")),
+		mcp.WithString("network_type", mcp.Description("Cluster network plugin; use Other to install a custom CNI after creation (default: OVNKubernetes)"), mcp.Enum(ocm.NetworkTypeOVNKubernetes, ocm.NetworkTypeOther)),
+		mcp.WithString("machine_cidr", mcp.Description("IPv4 CIDR of the machine network; must contain the VPC subnets (default: 10.0.0.0/16)")),
+		mcp.WithString("service_cidr", mcp.Description("IPv4 CIDR of the service network (default: 172.30.0.0/16)")),
+		mcp.WithString("pod_cidr", mcp.Description("IPv4 CIDR of the pod network (default: 10.128.0.0/14)")),
+		mcp.WithNumber("host_prefix", mcp.Description("Prefix length of the pod subnet assigned to each node, between 23 and 26 (default: 23)")),
+		mcp.WithObject("aws_tags", mcp.Description("AWS tags applied to every AWS resource the cluster creates, as key/value pairs; the aws: and red-hat- prefixes are reserved")),
+		mcp.WithObject("properties", mcp.Description("Extra OCM cluster properties as key/value pairs")),
+	}, options...)
+}
+
+// parseClusterCreateSpec extracts a ROSA HCP cluster create spec from the tool arguments
+func parseClusterCreateSpec(ctr mcp.CallToolRequest) (*ocm.ClusterCreateSpec, error) {
 	args := ctr.GetArguments()
 
 	// Extract required parameters with safe type assertions
-	clusterName, ok := args["cluster_name"].(string)
-	if !ok || clusterName == "" {
-		return NewTextResult("", errors.New("missing required argument: cluster_name")), nil
+	spec := &ocm.ClusterCreateSpec{}
+	for _, field := range []struct {
+		key    string
+		target *string
+	}{
+		{"cluster_name", &spec.Name},
+		{"aws_account_id", &spec.AWSAccountID},
+		{"billing_account_id", &spec.BillingAccountID},
+		{"role_arn", &spec.InstallerRoleARN},
+		{"operator_role_prefix", &spec.OperatorRolePrefix},
+		{"oidc_config_id", &spec.OIDCConfigID},
+		{"support_role_arn", &spec.SupportRoleARN},
+		{"worker_role_arn", &spec.WorkerRoleARN},
+		{"rosa_creator_arn", &spec.CreatorARN},
+	} {
+		value, ok := args[field.key].(string)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("missing required argument: %s", field.key)
+		}
+		*field.target = value
 	}
 
-	awsAccountID, ok := args["aws_account_id"].(string)
-	if !ok || awsAccountID == "" {
-		return NewTextResult("", errors.New("missing required argument: aws_account_id")), nil
+	spec.SubnetIDs = parseStringArray(args, "subnet_ids")
+	if len(spec.SubnetIDs) == 0 {
+		return nil, errors.New("missing required argument: subnet_ids (must be non-empty array)")
 	}
 
-	billingAccountID, ok := args["billing_account_id"].(string)
-	if !ok || billingAccountID == "" {
-		return NewTextResult("", errors.New("missing required argument: billing_account_id")), nil
+	spec.AvailabilityZones = parseStringArray(args, "availability_zones")
+	if len(spec.AvailabilityZones) == 0 {
+		return nil, errors.New("missing required argument: availability_zones (must be non-empty array)")
 	}
 
-	roleArn, ok := args["role_arn"].(string)
-	if !ok || roleArn == "" {
-		return NewTextResult("", errors.New("missing required argument: role_arn")), nil
-	}
+	// Handle region parameter with default using mcp.ParseString
+	spec.Region = mcp.ParseString(ctr, "region", "us-east-1")
 
-	operatorRolePrefix, ok := args["operator_role_prefix"].(string)
-	if !ok || operatorRolePrefix == "" {
-		return NewTextResult("", errors.New("missing required argument: operator_role_prefix")), nil
-	}
+	// Handle optional parameters; empty values fall back to the defaults in the ocm package
+	spec.MultiArchEnabled = mcp.ParseBoolean(ctr, "multi_arch_enabled", false)
+	spec.Version = mcp.ParseString(ctr, "version", "")
+	spec.ChannelGroup = mcp.ParseString(ctr, "channel_group", "")
+	spec.ComputeMachineType = mcp.ParseString(ctr, "compute_machine_type", "")
+	spec.BillingModel = mcp.ParseString(ctr, "billing_model", "")
 
-	oidcConfigID, ok := args["oidc_config_id"].(string)
-	if !ok || oidcConfigID == "" {
-		return NewTextResult("", errors.New("missing required argument: oidc_config_id")), nil
+	var err error
+	if spec.MultiAZ, err = parseOptionalBool(args, "multi_az"); err != nil {
+		return nil, err
 	}
-
-	supportRoleArn, ok := args["support_role_arn"].(string)
-	if !ok || supportRoleArn == "" {
-		return NewTextResult("", errors.New("missing required argument: support_role_arn")), nil
+	if spec.STSAutoMode, err = parseOptionalBool(args, "sts_auto_mode"); err != nil {
+		return nil, err
 	}
 
-	workerRoleArn, ok := args["worker_role_arn"].(string)
-	if !ok || workerRoleArn == "" {
-		return NewTextResult("", errors.New("missing required argument: worker_role_arn")), nil
+	if spec.Scaling, err = parseNodePoolScaling(args); err != nil {
+		return nil, err
 	}
 
-	rosaCreatorArn, ok := args["rosa_creator_arn"].(string)
-	if !ok || rosaCreatorArn == "" {
-		return NewTextResult("", errors.New("missing required argument: rosa_creator_arn")), nil
-	}
+	spec.Private = mcp.ParseBoolean(ctr, "private", false)
+	spec.HTTPProxy = mcp.ParseString(ctr, "http_proxy", "")
+	spec.HTTPSProxy = mcp.ParseString(ctr, "https_proxy", "")
+	spec.NoProxy = parseStringArray(args, "no_proxy")
+	spec.AdditionalTrustBundle = mcp.ParseString(ctr, "additional_trust_bundle", "")
+	spec.KMSKeyARN = mcp.ParseString(ctr, "kms_key_arn", "")
+	spec.EtcdEncryptionKMSKeyARN = mcp.ParseString(ctr, "etcd_encryption_kms_key_arn", "")
 
-	// Handle subnet_ids array parameter
-	subnetIDs := make([]string, 0)
-	if subnetIDsArg, ok := args["subnet_ids"].([]interface{}); ok {
-		for _, subnetID := range subnetIDsArg {
-			if subnetIDStr, ok := subnetID.(string); ok {
-				subnetIDs = append(subnetIDs, subnetIDStr)
-			}
-		}
+	network := &ocm.ClusterNetwork{
+		Type:        mcp.ParseString(ctr, "network_type", ""),
+		MachineCIDR: mcp.ParseString(ctr, "machine_cidr", ""),
+		ServiceCIDR: mcp.ParseString(ctr, "service_cidr", ""),
+		PodCIDR:     mcp.ParseString(ctr, "pod_cidr", ""),
 	}
-	if len(subnetIDs) == 0 {
-		return NewTextResult("", errors.New("missing required argument: subnet_ids (must be non-empty array)")), nil
+	if network.HostPrefix, err = parseOptionalInt(args, "host_prefix"); err != nil {
+		return nil, err
 	}
+	spec.Network = network
 
-	// Handle availability_zones array parameter
-	availabilityZones := make([]string, 0)
-	if availabilityZonesArg, ok := args["availability_zones"].([]interface{}); ok {
-		for _, az := range availabilityZonesArg {
-			if azStr, ok := az.(string); ok {
-				availabilityZones = append(availabilityZones, azStr)
-			}
-		}
+	if spec.AWSTags, err = parseStringMap(args, "aws_tags"); err != nil {
+		return nil, err
 	}
-	if len(availabilityZones) == 0 {
-		return NewTextResult("", errors.New("missing required argument: availability_zones (must be non-empty array)")), nil
+	if spec.Properties, err = parseStringMap(args, "properties"); err != nil {
+		return nil, err
 	}
 
-	// Handle region parameter with default using mcp.ParseString
-	region := mcp.ParseString(ctr, "region", "us-east-1")
-
-	// Handle optional boolean parameters with defaults
-	multiArchEnabled := mcp.ParseBoolean(ctr, "multi_arch_enabled", false)
-
-	s.logToolCall("create_rosa_hcp_cluster", map[string]interface{}{
-		"cluster_name":         clusterName,
-		"aws_account_id":       awsAccountID,
-		"billing_account_id":   billingAccountID,
-		"role_arn":             roleArn,
-		"operator_role_prefix": operatorRolePrefix,
-		"oidc_config_id":       oidcConfigID,
-		"support_role_arn":     supportRoleArn,
-		"worker_role_arn":      workerRoleArn,
-		"rosa_creator_arn":     rosaCreatorArn,
-		"subnet_ids":           subnetIDs,
-		"availability_zones":   availabilityZones,
-		"region":               region,
-		"multi_arch_enabled":   multiArchEnabled,
-	})
+	return spec, nil
+}
 
-	// Get authenticated OCM client
-	client, err := s.getAuthenticatedOCMClient(ctx)
+// handleValidateClusterConfig handles the validate_cluster_config tool
+func (s *Server) handleValidateClusterConfig(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	spec, err := parseClusterCreateSpec(ctr)
 	if err != nil {
-		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+		return NewTextResult("", err), nil
 	}
-	defer client.Close()
 
-	// Call OCM client with ROSA HCP parameters (no validation, pass directly to OCM API)
-	cluster, err := client.CreateROSAHCPCluster(
-		clusterName, awsAccountID, billingAccountID, roleArn,
-		operatorRolePrefix, oidcConfigID, supportRoleArn, workerRoleArn, rosaCreatorArn,
-		subnetIDs, availabilityZones, region,
-		multiArchEnabled,
-	)
-	if errorResult := handleOCMError(err, "cluster creation"); errorResult != nil {
-		return errorResult, nil
-	}
+	// Proxy URLs can carry credentials
+	s.logToolCall("validate_cluster_config", redactArgs(ctr.GetArguments(), "http_proxy", "https_proxy"))
 
-	// Format response using MCP layer formatter
-	formattedResponse := formatClusterCreateResponse(cluster)
-	return NewTextResult(formattedResponse, nil), nil
+	return NewTextResult(formatClusterConfigValidation(spec, spec.Validate()), nil), nil
+}
+
+// handleCreateROSAHCPCluster handles the create_rosa_hcp_cluster tool
+func (s *Server) handleCreateROSAHCPCluster(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	spec, err := parseClusterCreateSpec(ctr)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	// Proxy URLs can carry credentials
+	s.logToolCall("create_rosa_hcp_cluster", redactArgs(ctr.GetArguments(), "http_proxy", "https_proxy"))
+
+	// A dry run reports validation failures in its result instead of failing the tool call
+	dryRun := mcp.ParseBoolean(ctr, "dry_run", false)
+	if !dryRun {
+		if err := spec.Validate(); err != nil {
+			return NewTextResult("", err), nil
+		}
+	}
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	if verificationID := mcp.ParseString(ctr, "network_verification_id", ""); verificationID != "" {
+		err := s.checkNetworkVerification(client, verificationID, spec)
+		if errorResult := handleOCMError(err, "network verification check"); errorResult != nil {
+			return errorResult, nil
+		}
+	}
+
+	if dryRun {
+		result, err := client.DryRunROSAHCPCluster(spec)
+		if errorResult := handleOCMError(err, "cluster creation dry run"); errorResult != nil {
+			return errorResult, nil
+		}
+		return NewTextResult(formatClusterDryRunResponse(result), nil), nil
+	}
+
+	cluster, err := client.CreateROSAHCPCluster(spec)
+	if errorResult := handleOCMError(err, "cluster creation"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatClusterCreateResponse(cluster)
+	return NewTextResult(formattedResponse, nil), nil
 }
 
 // handleGetROSAHCPPrerequisitesGuide handles the get_rosa_hcp_prerequisites_guide tool
@@ -304,7 +910,8 @@ func (s *Server) handleGetROSAHCPPrerequisitesGuide(ctx context.Context, ctr mcp
 // handleSetupHTPasswdIdentityProvider handles the setup_htpasswd_identity_provider tool
 func (s *Server) handleSetupHTPasswdIdentityProvider(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	params := ctr.GetArguments()
-	s.logToolCall("setup_htpasswd_identity_provider", params)
+	// Never log user passwords
+	s.logToolCall("setup_htpasswd_identity_provider", redactArgs(params, "users"))
 
 	// Extract required parameters
 	clusterID, ok := params["cluster_id"].(string)
@@ -328,6 +935,12 @@ func (s *Server) handleSetupHTPasswdIdentityProvider(ctx context.Context, ctr mc
 		overwriteExisting = ow
 	}
 
+	overwriteModeArg, _ := params["overwrite_mode"].(string)
+	overwriteMode, err := ocm.ParseHTPasswdOverwriteMode(overwriteModeArg)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
 	// Get authenticated OCM client
 	client, err := s.getAuthenticatedOCMClient(ctx)
 	if err != nil {
@@ -336,7 +949,7 @@ func (s *Server) handleSetupHTPasswdIdentityProvider(ctx context.Context, ctr mc
 	defer client.Close()
 
 	// Setup HTPasswd identity provider using OCM client
-	idp, err := client.SetupHTPasswdIdentityProvider(clusterID, name, mappingMethod, params, overwriteExisting)
+	result, err := client.SetupHTPasswdIdentityProvider(clusterID, name, mappingMethod, params, overwriteExisting, overwriteMode)
 	if errorResult := handleOCMError(err, "failed to setup HTPasswd identity provider"); errorResult != nil {
 		return errorResult, nil
 	}
@@ -347,17 +960,2027 @@ func (s *Server) handleSetupHTPasswdIdentityProvider(ctx context.Context, ctr mc
 		return errorResult, nil
 	}
 
-	// Count the number of users created
-	userCount := 0
-	if idp.Htpasswd() != nil && idp.Htpasswd().Users() != nil {
-		userCount = len(idp.Htpasswd().Users().Slice())
+	// Format response using MCP layer formatter
+	formattedResponse := FormatHTPasswdIdentityProviderResult(result, cluster)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// parseIdentityProviderSpec extracts the cluster and the settings shared by the identity provider setup tools
+func parseIdentityProviderSpec(args map[string]interface{}, defaultName string) (string, ocm.IdentityProviderSpec, error) {
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return "", ocm.IdentityProviderSpec{}, errors.New("missing required argument: cluster_id")
+	}
+
+	spec := ocm.IdentityProviderSpec{Name: defaultName, MappingMethod: "claim"}
+	if name, ok := args["name"].(string); ok && name != "" {
+		spec.Name = name
+	}
+	if mappingMethod, ok := args["mapping_method"].(string); ok && mappingMethod != "" {
+		spec.MappingMethod = mappingMethod
+	}
+	return clusterID, spec, nil
+}
+
+// setupIdentityProvider creates an identity provider from a typed configuration and formats the result
+func (s *Server) setupIdentityProvider(ctx context.Context, clusterID string, config ocm.IdentityProviderConfig) (*mcp.CallToolResult, error) {
+	// Validate before authenticating so configuration errors are reported immediately
+	if err := config.Validate(); err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	cluster, err := client.GetCluster(clusterID)
+	if errorResult := handleOCMError(err, "failed to get cluster"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	idp, err := client.SetupIdentityProvider(clusterID, config)
+	if errorResult := handleOCMError(err, "identity provider setup"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatIdentityProviderCreatedResponse(cluster, idp)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleSetupGithubIdentityProvider handles the setup_github_identity_provider tool
+func (s *Server) handleSetupGithubIdentityProvider(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+	s.logToolCall("setup_github_identity_provider", redactArgs(args, "client_secret"))
+
+	clusterID, common, err := parseIdentityProviderSpec(args, "github")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	spec := &ocm.GithubIdentityProviderSpec{
+		IdentityProviderSpec: common,
+		ClientID:             mcp.ParseString(ctr, "client_id", ""),
+		ClientSecret:         mcp.ParseString(ctr, "client_secret", ""),
+		Hostname:             mcp.ParseString(ctr, "hostname", ""),
+		CA:                   mcp.ParseString(ctr, "ca", ""),
+		Organizations:        parseStringArray(args, "organizations"),
+		Teams:                parseStringArray(args, "teams"),
+	}
+	return s.setupIdentityProvider(ctx, clusterID, spec)
+}
+
+// handleSetupGitlabIdentityProvider handles the setup_gitlab_identity_provider tool
+func (s *Server) handleSetupGitlabIdentityProvider(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+	s.logToolCall("setup_gitlab_identity_provider", redactArgs(args, "client_secret"))
+
+	clusterID, common, err := parseIdentityProviderSpec(args, "gitlab")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	spec := &ocm.GitlabIdentityProviderSpec{
+		IdentityProviderSpec: common,
+		URL:                  mcp.ParseString(ctr, "url", "https://gitlab.com"),
+		ClientID:             mcp.ParseString(ctr, "client_id", ""),
+		ClientSecret:         mcp.ParseString(ctr, "client_secret", ""),
+		CA:                   mcp.ParseString(ctr, "ca", ""),
+	}
+	return s.setupIdentityProvider(ctx, clusterID, spec)
+}
+
+// handleSetupGoogleIdentityProvider handles the setup_google_identity_provider tool
+func (s *Server) handleSetupGoogleIdentityProvider(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+	s.logToolCall("setup_google_identity_provider", redactArgs(args, "client_secret"))
+
+	clusterID, common, err := parseIdentityProviderSpec(args, "google")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	spec := &ocm.GoogleIdentityProviderSpec{
+		IdentityProviderSpec: common,
+		ClientID:             mcp.ParseString(ctr, "client_id", ""),
+		ClientSecret:         mcp.ParseString(ctr, "client_secret", ""),
+		HostedDomain:         mcp.ParseString(ctr, "hosted_domain", ""),
+	}
+	return s.setupIdentityProvider(ctx, clusterID, spec)
+}
+
+// handleSetupOpenIDIdentityProvider handles the setup_openid_identity_provider tool
+func (s *Server) handleSetupOpenIDIdentityProvider(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+	s.logToolCall("setup_openid_identity_provider", redactArgs(args, "client_secret"))
+
+	clusterID, common, err := parseIdentityProviderSpec(args, "openid")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	spec := &ocm.OpenIDIdentityProviderSpec{
+		IdentityProviderSpec:    common,
+		Issuer:                  mcp.ParseString(ctr, "issuer_url", ""),
+		ClientID:                mcp.ParseString(ctr, "client_id", ""),
+		ClientSecret:            mcp.ParseString(ctr, "client_secret", ""),
+		CA:                      mcp.ParseString(ctr, "ca", ""),
+		ExtraScopes:             parseStringArray(args, "extra_scopes"),
+		EmailClaims:             parseStringArray(args, "email_claims"),
+		NameClaims:              parseStringArray(args, "name_claims"),
+		PreferredUsernameClaims: parseStringArray(args, "username_claims"),
+		GroupsClaims:            parseStringArray(args, "groups_claims"),
+	}
+	return s.setupIdentityProvider(ctx, clusterID, spec)
+}
+
+// handleSetupLDAPIdentityProvider handles the setup_ldap_identity_provider tool
+func (s *Server) handleSetupLDAPIdentityProvider(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+	s.logToolCall("setup_ldap_identity_provider", redactArgs(args, "bind_password"))
+
+	clusterID, common, err := parseIdentityProviderSpec(args, "ldap")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	spec := &ocm.LDAPIdentityProviderSpec{
+		IdentityProviderSpec:        common,
+		URL:                         mcp.ParseString(ctr, "url", ""),
+		BindDN:                      mcp.ParseString(ctr, "bind_dn", ""),
+		BindPassword:                mcp.ParseString(ctr, "bind_password", ""),
+		CA:                          mcp.ParseString(ctr, "ca", ""),
+		Insecure:                    mcp.ParseBoolean(ctr, "insecure", false),
+		IDAttributes:                parseStringArray(args, "id_attributes"),
+		PreferredUsernameAttributes: parseStringArray(args, "username_attributes"),
+		NameAttributes:              parseStringArray(args, "name_attributes"),
+		EmailAttributes:             parseStringArray(args, "email_attributes"),
+	}
+	return s.setupIdentityProvider(ctx, clusterID, spec)
+}
+
+// handleDeleteCluster handles the delete_cluster tool
+func (s *Server) handleDeleteCluster(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	confirmationToken := mcp.ParseString(ctr, "confirmation_token", "")
+
+	// Never log the confirmation token itself
+	s.logToolCall("delete_cluster", map[string]interface{}{
+		"cluster_id":         clusterID,
+		"confirmation_token": confirmationToken != "",
+	})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	// Always resolve the cluster first so the summary reflects its current state
+	cluster, err := client.GetCluster(clusterID)
+	if errorResult := handleOCMError(err, "failed to get cluster"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Phase 1: no token supplied, describe what will be deleted and issue a token
+	if confirmationToken == "" {
+		token, expiresAt, err := s.confirmations.Issue("delete_cluster", clusterID)
+		if err != nil {
+			return NewTextResult("", err), nil
+		}
+		return NewTextResult(formatClusterDeleteConfirmation(cluster, token, expiresAt), nil), nil
+	}
+
+	// Phase 2: token supplied, validate it before issuing the DELETE
+	if err := s.confirmations.Consume(confirmationToken, "delete_cluster", clusterID); err != nil {
+		return NewTextResult("", fmt.Errorf("deletion not confirmed: %w. Call delete_cluster without a confirmation_token to request a new one", err)), nil
+	}
+
+	err = client.DeleteCluster(clusterID)
+	if errorResult := handleOCMError(err, "cluster deletion"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatClusterDeleteResponse(cluster)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// parseNodePoolScaling extracts replicas or autoscaling bounds; returns nil if none were provided
+func parseNodePoolScaling(args map[string]interface{}) (*ocm.NodePoolScaling, error) {
+	replicas, err := parseOptionalInt(args, "replicas")
+	if err != nil {
+		return nil, err
+	}
+	minReplicas, err := parseOptionalInt(args, "min_replicas")
+	if err != nil {
+		return nil, err
+	}
+	maxReplicas, err := parseOptionalInt(args, "max_replicas")
+	if err != nil {
+		return nil, err
+	}
+
+	if replicas == nil && minReplicas == nil && maxReplicas == nil {
+		return nil, nil
+	}
+
+	scaling := &ocm.NodePoolScaling{
+		Replicas:    replicas,
+		MinReplicas: minReplicas,
+		MaxReplicas: maxReplicas,
+	}
+	if err := scaling.Validate(); err != nil {
+		return nil, err
+	}
+	return scaling, nil
+}
+
+// parseNodePoolTaintsArg extracts taints from tool arguments; returns nil if the argument is absent
+func parseNodePoolTaintsArg(args map[string]interface{}) ([]ocm.NodePoolTaint, error) {
+	if _, exists := args["taints"]; !exists {
+		return nil, nil
+	}
+	return ocm.ParseNodePoolTaints(parseStringArray(args, "taints"))
+}
+
+// handleListNodePools handles the list_node_pools tool
+func (s *Server) handleListNodePools(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	s.logToolCall("list_node_pools", map[string]interface{}{"cluster_id": clusterID})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	nodePools, err := client.GetNodePools(clusterID)
+	if errorResult := handleOCMError(err, "failed to list node pools"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatNodePoolsResponse(clusterID, nodePools)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleCreateNodePool handles the create_node_pool tool
+func (s *Server) handleCreateNodePool(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return NewTextResult("", errors.New("missing required argument: name")), nil
+	}
+
+	subnetID, ok := args["subnet_id"].(string)
+	if !ok || subnetID == "" {
+		return NewTextResult("", errors.New("missing required argument: subnet_id")), nil
+	}
+
+	scaling, err := parseNodePoolScaling(args)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	if scaling == nil {
+		return NewTextResult("", errors.New("missing required argument: replicas (or min_replicas and max_replicas for autoscaling)")), nil
+	}
+
+	labels, err := parseStringMap(args, "labels")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	taints, err := parseNodePoolTaintsArg(args)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	autoRepair := mcp.ParseBoolean(ctr, "auto_repair", true)
+
+	spec := &ocm.NodePoolSpec{
+		Name:         name,
+		InstanceType: mcp.ParseString(ctr, "instance_type", "m5.xlarge"),
+		SubnetID:     subnetID,
+		Version:      mcp.ParseString(ctr, "version", ""),
+		Scaling:      scaling,
+		Labels:       labels,
+		Taints:       taints,
+		AutoRepair:   &autoRepair,
+	}
+
+	s.logToolCall("create_node_pool", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	nodePool, err := client.CreateNodePool(clusterID, spec)
+	if errorResult := handleOCMError(err, "node pool creation"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatNodePoolResponse("Node Pool Created", clusterID, nodePool)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleScaleNodePool handles the scale_node_pool tool
+func (s *Server) handleScaleNodePool(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	nodePoolID, ok := args["node_pool_id"].(string)
+	if !ok || nodePoolID == "" {
+		return NewTextResult("", errors.New("missing required argument: node_pool_id")), nil
+	}
+
+	scaling, err := parseNodePoolScaling(args)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	if scaling == nil {
+		return NewTextResult("", errors.New("missing required argument: replicas (or min_replicas and max_replicas for autoscaling)")), nil
+	}
+
+	s.logToolCall("scale_node_pool", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	nodePool, err := client.UpdateNodePool(clusterID, nodePoolID, &ocm.NodePoolSpec{Scaling: scaling})
+	if errorResult := handleOCMError(err, "node pool scaling"); errorResult != nil {
+		return errorResult, nil
 	}
 
 	// Format response using MCP layer formatter
-	formattedResponse := FormatHTPasswdIdentityProviderResult(idp, cluster, userCount)
+	formattedResponse := formatNodePoolResponse("Node Pool Scaled", clusterID, nodePool)
 	return NewTextResult(formattedResponse, nil), nil
 }
 
+// handleUpdateNodePool handles the update_node_pool tool
+func (s *Server) handleUpdateNodePool(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	nodePoolID, ok := args["node_pool_id"].(string)
+	if !ok || nodePoolID == "" {
+		return NewTextResult("", errors.New("missing required argument: node_pool_id")), nil
+	}
+
+	labels, err := parseStringMap(args, "labels")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	taints, err := parseNodePoolTaintsArg(args)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	autoRepair, err := parseOptionalBool(args, "auto_repair")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	if labels == nil && taints == nil && autoRepair == nil {
+		return NewTextResult("", errors.New("nothing to update: provide at least one of labels, taints or auto_repair")), nil
+	}
+
+	s.logToolCall("update_node_pool", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	nodePool, err := client.UpdateNodePool(clusterID, nodePoolID, &ocm.NodePoolSpec{
+		Labels:     labels,
+		Taints:     taints,
+		AutoRepair: autoRepair,
+	})
+	if errorResult := handleOCMError(err, "node pool update"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatNodePoolResponse("Node Pool Updated", clusterID, nodePool)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleDeleteNodePool handles the delete_node_pool tool
+func (s *Server) handleDeleteNodePool(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	nodePoolID, ok := args["node_pool_id"].(string)
+	if !ok || nodePoolID == "" {
+		return NewTextResult("", errors.New("missing required argument: node_pool_id")), nil
+	}
+
+	confirmationToken := mcp.ParseString(ctr, "confirmation_token", "")
+
+	// Never log the confirmation token itself
+	s.logToolCall("delete_node_pool", map[string]interface{}{
+		"cluster_id":         clusterID,
+		"node_pool_id":       nodePoolID,
+		"confirmation_token": confirmationToken != "",
+	})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	nodePool, err := client.GetNodePool(clusterID, nodePoolID)
+	if errorResult := handleOCMError(err, "failed to get node pool"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Confirmation tokens are bound to the cluster and node pool pair
+	resourceID := clusterID + "/" + nodePoolID
+
+	// Phase 1: no token supplied, describe what will be deleted and issue a token
+	if confirmationToken == "" {
+		token, expiresAt, err := s.confirmations.Issue("delete_node_pool", resourceID)
+		if err != nil {
+			return NewTextResult("", err), nil
+		}
+		return NewTextResult(formatNodePoolDeleteConfirmation(clusterID, nodePool, token, expiresAt), nil), nil
+	}
+
+	// Phase 2: token supplied, validate it before issuing the DELETE
+	if err := s.confirmations.Consume(confirmationToken, "delete_node_pool", resourceID); err != nil {
+		return NewTextResult("", fmt.Errorf("deletion not confirmed: %w. Call delete_node_pool without a confirmation_token to request a new one", err)), nil
+	}
+
+	err = client.DeleteNodePool(clusterID, nodePoolID)
+	if errorResult := handleOCMError(err, "node pool deletion"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(fmt.Sprintf("✓ Deletion of node pool '%s' on cluster %s initiated. Use 'list_node_pools' to check status.", nodePoolID, clusterID), nil), nil
+}
+
+// handleListUpgradeVersions handles the list_upgrade_versions tool
+func (s *Server) handleListUpgradeVersions(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	s.logToolCall("list_upgrade_versions", map[string]interface{}{"cluster_id": clusterID})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	cluster, err := client.GetCluster(clusterID)
+	if errorResult := handleOCMError(err, "failed to get cluster"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	nodePools, err := client.GetNodePools(clusterID)
+	if errorResult := handleOCMError(err, "failed to list node pools"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatUpgradeVersionsResponse(cluster, nodePools)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleScheduleUpgrade handles the schedule_upgrade tool
+func (s *Server) handleScheduleUpgrade(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	nodePoolID := mcp.ParseString(ctr, "node_pool_id", "")
+	version := mcp.ParseString(ctr, "version", "")
+	scheduleType := mcp.ParseString(ctr, "schedule_type", "now")
+	confirmationToken := mcp.ParseString(ctr, "confirmation_token", "")
+
+	// Build the schedule from the requested schedule type
+	spec := &ocm.UpgradeScheduleSpec{Version: version}
+	switch scheduleType {
+	case "now":
+		spec.NextRun = time.Now().UTC().Add(ocm.ManualUpgradeLeadTime)
+	case "scheduled":
+		nextRunArg := mcp.ParseString(ctr, "next_run", "")
+		if nextRunArg == "" {
+			return NewTextResult("", errors.New("missing required argument: next_run (required for scheduled upgrades)")), nil
+		}
+		nextRun, err := time.Parse(time.RFC3339, nextRunArg)
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("invalid next_run '%s': expected RFC3339 format, e.g. 2025-01-31T22:00:00Z", nextRunArg)), nil
+		}
+		if !nextRun.After(time.Now()) {
+			return NewTextResult("", fmt.Errorf("invalid next_run '%s': must be in the future", nextRunArg)), nil
+		}
+		spec.NextRun = nextRun.UTC()
+	case "recurring":
+		spec.Schedule = mcp.ParseString(ctr, "schedule", "")
+		if spec.Schedule == "" {
+			return NewTextResult("", errors.New("missing required argument: schedule (required for recurring upgrades)")), nil
+		}
+	default:
+		return NewTextResult("", fmt.Errorf("invalid schedule_type '%s': must be one of now, scheduled, recurring", scheduleType)), nil
+	}
+	if err := spec.Validate(); err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	// Never log the confirmation token itself
+	s.logToolCall("schedule_upgrade", redactArgs(args, "confirmation_token"))
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	cluster, err := client.GetCluster(clusterID)
+	if errorResult := handleOCMError(err, "failed to get cluster"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Node pool upgrades
+	if nodePoolID != "" {
+		if version != "" {
+			nodePool, err := client.GetNodePool(clusterID, nodePoolID)
+			if errorResult := handleOCMError(err, "failed to get node pool"); errorResult != nil {
+				return errorResult, nil
+			}
+			if err := checkUpgradeVersion(version, ocm.NodePoolAvailableUpgrades(cluster, nodePool)); err != nil {
+				return NewTextResult("", err), nil
+			}
+		}
+
+		policy, err := client.CreateNodePoolUpgradePolicy(clusterID, nodePoolID, spec)
+		if errorResult := handleOCMError(err, "node pool upgrade scheduling"); errorResult != nil {
+			return errorResult, nil
+		}
+		return NewTextResult(formatUpgradePolicyCreatedResponse(cluster, nodePoolID, policy), nil), nil
+	}
+
+	// Control plane upgrades
+	if version != "" {
+		if err := checkUpgradeVersion(version, cluster.Version().AvailableUpgrades()); err != nil {
+			return NewTextResult("", err), nil
+		}
+
+		missingGates, err := client.GetMissingVersionGates(cluster, version)
+		if errorResult := handleOCMError(err, "failed to check version gates"); errorResult != nil {
+			return errorResult, nil
+		}
+		if len(missingGates) > 0 {
+			// The token is bound to the reported gates, so gates that appear later are reported again
+			resourceID := versionGatesResourceID(clusterID, version, missingGates)
+			if confirmationToken == "" {
+				token, expiresAt, err := s.confirmations.Issue("acknowledge_version_gates", resourceID)
+				if err != nil {
+					return NewTextResult("", err), nil
+				}
+				return NewTextResult(formatVersionGatesResponse(cluster, version, missingGates, token, expiresAt), nil), nil
+			}
+			if err := s.confirmations.Consume(confirmationToken, "acknowledge_version_gates", resourceID); err != nil {
+				return NewTextResult("", fmt.Errorf("version gates not acknowledged: %w. Call schedule_upgrade without a confirmation_token to review the gates again", err)), nil
+			}
+			for _, gate := range missingGates {
+				err := client.AcknowledgeVersionGate(clusterID, gate.ID())
+				if errorResult := handleOCMError(err, "failed to acknowledge version gate"); errorResult != nil {
+					return errorResult, nil
+				}
+			}
+		}
+	}
+
+	policy, err := client.CreateControlPlaneUpgradePolicy(clusterID, spec)
+	if errorResult := handleOCMError(err, "control plane upgrade scheduling"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatUpgradePolicyCreatedResponse(cluster, "", policy)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// versionGatesResourceID identifies the version gates reported for an upgrade of a cluster to version
+func versionGatesResourceID(clusterID, version string, gates []*clustersmgmt.VersionGate) string {
+	gateIDs := make([]string, 0, len(gates))
+	for _, gate := range gates {
+		gateIDs = append(gateIDs, gate.ID())
+	}
+	sort.Strings(gateIDs)
+	return fmt.Sprintf("%s/%s/%s", clusterID, version, strings.Join(gateIDs, ","))
+}
+
+// checkUpgradeVersion verifies that version is one of the available upgrade versions
+func checkUpgradeVersion(version string, availableUpgrades []string) error {
+	for _, available := range availableUpgrades {
+		if available == version {
+			return nil
+		}
+	}
+	if len(availableUpgrades) == 0 {
+		return fmt.Errorf("version %s is not available: there are no available upgrades", version)
+	}
+	return fmt.Errorf("version %s is not available; available upgrades: %s", version, strings.Join(availableUpgrades, ", "))
+}
+
+// handleListUpgradePolicies handles the list_upgrade_policies tool
+func (s *Server) handleListUpgradePolicies(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	nodePoolID := mcp.ParseString(ctr, "node_pool_id", "")
+
+	s.logToolCall("list_upgrade_policies", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	var sections []upgradePolicySection
+
+	if nodePoolID == "" {
+		controlPlanePolicies, err := client.GetControlPlaneUpgradePolicies(clusterID)
+		if errorResult := handleOCMError(err, "failed to list control plane upgrade policies"); errorResult != nil {
+			return errorResult, nil
+		}
+		section := upgradePolicySection{title: "Control Plane"}
+		for _, policy := range controlPlanePolicies {
+			section.policies = append(section.policies, policy)
+		}
+		sections = append(sections, section)
+	}
+
+	nodePoolIDs := []string{nodePoolID}
+	if nodePoolID == "" {
+		nodePools, err := client.GetNodePools(clusterID)
+		if errorResult := handleOCMError(err, "failed to list node pools"); errorResult != nil {
+			return errorResult, nil
+		}
+		nodePoolIDs = nodePoolIDs[:0]
+		for _, nodePool := range nodePools {
+			nodePoolIDs = append(nodePoolIDs, nodePool.ID())
+		}
+	}
+
+	for _, id := range nodePoolIDs {
+		nodePoolPolicies, err := client.GetNodePoolUpgradePolicies(clusterID, id)
+		if errorResult := handleOCMError(err, "failed to list node pool upgrade policies"); errorResult != nil {
+			return errorResult, nil
+		}
+		section := upgradePolicySection{title: "Node Pool " + id}
+		for _, policy := range nodePoolPolicies {
+			section.policies = append(section.policies, policy)
+		}
+		sections = append(sections, section)
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatUpgradePoliciesResponse(clusterID, sections)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleCancelUpgrade handles the cancel_upgrade tool
+func (s *Server) handleCancelUpgrade(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	policyID, ok := args["policy_id"].(string)
+	if !ok || policyID == "" {
+		return NewTextResult("", errors.New("missing required argument: policy_id")), nil
+	}
+
+	nodePoolID := mcp.ParseString(ctr, "node_pool_id", "")
+
+	s.logToolCall("cancel_upgrade", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	if nodePoolID != "" {
+		err = client.CancelNodePoolUpgradePolicy(clusterID, nodePoolID, policyID)
+	} else {
+		err = client.CancelControlPlaneUpgradePolicy(clusterID, policyID)
+	}
+	if errorResult := handleOCMError(err, "upgrade cancellation"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	target := "control plane"
+	if nodePoolID != "" {
+		target = fmt.Sprintf("node pool '%s'", nodePoolID)
+	}
+	return NewTextResult(fmt.Sprintf("✓ Upgrade policy %s for the %s of cluster %s cancelled", policyID, target, clusterID), nil), nil
+}
+
+// logFollowPollInterval is how often followed cluster logs are polled for new lines
+const logFollowPollInterval = 10 * time.Second
+
+// maxLogFollowTimeout caps how long a single tool call may follow cluster logs
+const maxLogFollowTimeout = 2 * time.Hour
+
+// logActiveStates lists the cluster states during which each log is still being written
+var logActiveStates = map[ocm.LogType][]string{
+	ocm.InstallLog:   {"validating", "waiting", "pending", "installing"},
+	ocm.UninstallLog: {"uninstalling"},
+}
+
+// handleGetInstallLogs handles the get_install_logs tool
+func (s *Server) handleGetInstallLogs(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.handleClusterLogs(ctx, ctr, "get_install_logs", ocm.InstallLog)
+}
+
+// handleGetUninstallLogs handles the get_uninstall_logs tool
+func (s *Server) handleGetUninstallLogs(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.handleClusterLogs(ctx, ctr, "get_uninstall_logs", ocm.UninstallLog)
+}
+
+// handleClusterLogs implements the install and uninstall log tools
+func (s *Server) handleClusterLogs(ctx context.Context, ctr mcp.CallToolRequest, toolName string, logType ocm.LogType) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	offset, err := parseOptionalInt(args, "offset")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	tail, err := parseOptionalInt(args, "tail")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	if offset != nil && tail != nil {
+		return NewTextResult("", errors.New("offset and tail cannot be combined")), nil
+	}
+	if (offset != nil && *offset < 0) || (tail != nil && *tail < 0) {
+		return NewTextResult("", errors.New("offset and tail must be non-negative")), nil
+	}
+
+	follow := mcp.ParseBoolean(ctr, "follow", false)
+	timeout := time.Duration(mcp.ParseInt(ctr, "timeout_seconds", 1800)) * time.Second
+	if timeout <= 0 || timeout > maxLogFollowTimeout {
+		return NewTextResult("", fmt.Errorf("timeout_seconds must be between 1 and %d", int(maxLogFollowTimeout.Seconds()))), nil
+	}
+
+	s.logToolCall(toolName, args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	startOffset, startTail := 0, 0
+	if offset != nil {
+		startOffset = *offset
+	}
+	if tail != nil {
+		startTail = *tail
+	}
+
+	// Following needs the line offset of the returned content, which an OCM tail does not
+	// report, so the full log is fetched once and tailed locally
+	fetchTail := startTail
+	if follow {
+		fetchTail = 0
+	}
+	content, err := client.GetClusterLogs(clusterID, logType, startOffset, fetchTail)
+	if errorResult := handleOCMError(err, "failed to get "+string(logType)+" logs"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	if !follow {
+		return NewTextResult(formatClusterLogsResponse(clusterID, logType, content, ""), nil), nil
+	}
+
+	if startTail > 0 {
+		content, startOffset = tailLines(content, startTail)
+	}
+	content, note := s.followClusterLogs(ctx, ctr, client, clusterID, logType, content, startOffset, timeout)
+	return NewTextResult(formatClusterLogsResponse(clusterID, logType, content, note), nil), nil
+}
+
+// followClusterLogs polls a cluster log for new lines, streaming them as progress notifications,
+// until the cluster leaves the state in which the log is written, the timeout elapses or the
+// client cancels. It returns all log content seen and a note describing why following stopped.
+func (s *Server) followClusterLogs(
+	ctx context.Context,
+	ctr mcp.CallToolRequest,
+	client *ocm.Client,
+	clusterID string,
+	logType ocm.LogType,
+	initial string,
+	offset int,
+	timeout time.Duration,
+) (string, string) {
+	reporter := s.newProgressReporter(ctr)
+	deadline := time.Now().Add(timeout)
+
+	var collected strings.Builder
+	content := initial
+
+	for {
+		// Only consume complete lines; a partial last line is fetched again on the next poll
+		if lines := completeLines(content); lines != "" {
+			collected.WriteString(lines)
+			offset += strings.Count(lines, "\n")
+			reporter.Report(ctx, lines)
+		}
+
+		cluster, err := client.GetCluster(clusterID)
+		if err != nil {
+			if ocm.IsNotFoundError(err) {
+				return collected.String(), "Cluster no longer exists; log following finished."
+			}
+			return collected.String(), fmt.Sprintf("Stopped following: %v", err)
+		}
+
+		state := string(cluster.State())
+		if !slices.Contains(logActiveStates[logType], state) {
+			// Pick up any final lines written before the state changed
+			if final, err := client.GetClusterLogs(clusterID, logType, offset, 0); err == nil && final != "" {
+				collected.WriteString(final)
+				reporter.Report(ctx, final)
+			}
+			return collected.String(), fmt.Sprintf("Cluster is now in state '%s'; log following finished.", state)
+		}
+
+		if time.Now().After(deadline) {
+			return collected.String(), fmt.Sprintf("Timed out after %s while the cluster is still '%s'. Call again with offset=%d to continue.", timeout, state, offset)
+		}
+
+		select {
+		case <-ctx.Done():
+			return collected.String(), fmt.Sprintf("Cancelled by client. Call again with offset=%d to continue.", offset)
+		case <-time.After(logFollowPollInterval):
+		}
+
+		content, err = client.GetClusterLogs(clusterID, logType, offset, 0)
+		if err != nil {
+			return collected.String(), fmt.Sprintf("Stopped following: %v", err)
+		}
+	}
+}
+
+// tailLines returns the content from the start of its last n complete lines, including any
+// partial last line, and the number of complete lines before it
+func tailLines(content string, n int) (string, int) {
+	skip := strings.Count(content, "\n") - n
+	if skip <= 0 {
+		return content, 0
+	}
+	start := 0
+	for i := 0; i < skip; i++ {
+		start += strings.Index(content[start:], "\n") + 1
+	}
+	return content[start:], skip
+}
+
+// completeLines returns the content up to and including its last line break
+func completeLines(content string) string {
+	idx := strings.LastIndex(content, "\n")
+	if idx < 0 {
+		return ""
+	}
+	return content[:idx+1]
+}
+
+const (
+	// clusterWaitInitialInterval is the first polling interval when waiting for a cluster state
+	clusterWaitInitialInterval = 5 * time.Second
+
+	// clusterWaitMaxInterval caps the polling backoff when waiting for a cluster state
+	clusterWaitMaxInterval = time.Minute
+
+	// maxClusterWaitTimeout caps how long a single tool call may wait for a cluster state
+	maxClusterWaitTimeout = 2 * time.Hour
+
+	// clusterStateDeleted is the pseudo state used to wait for a cluster to be removed
+	clusterStateDeleted = "deleted"
+)
+
+// handleWaitForClusterState handles the wait_for_cluster_state tool
+func (s *Server) handleWaitForClusterState(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	targetState := mcp.ParseString(ctr, "target_state", "ready")
+	timeout := time.Duration(mcp.ParseInt(ctr, "timeout_seconds", 3600)) * time.Second
+	if timeout <= 0 || timeout > maxClusterWaitTimeout {
+		return NewTextResult("", fmt.Errorf("timeout_seconds must be between 1 and %d", int(maxClusterWaitTimeout.Seconds()))), nil
+	}
+
+	s.logToolCall("wait_for_cluster_state", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	reporter := s.newProgressReporter(ctr)
+	start := time.Now()
+	deadline := start.Add(timeout)
+	interval := clusterWaitInitialInterval
+
+	var lastCluster *clustersmgmt.Cluster
+	for {
+		cluster, err := client.GetCluster(clusterID)
+		if err != nil {
+			if ocm.IsNotFoundError(err) && (targetState == clusterStateDeleted || lastCluster != nil) {
+				if targetState == clusterStateDeleted {
+					return NewTextResult(fmt.Sprintf("✓ Cluster %s has been deleted (waited %s)", clusterID, time.Since(start).Round(time.Second)), nil), nil
+				}
+				return NewTextResult("", fmt.Errorf("cluster %s was deleted while waiting for state '%s'", clusterID, targetState)), nil
+			}
+			if errorResult := handleOCMError(err, "failed to get cluster"); errorResult != nil {
+				return errorResult, nil
+			}
+		}
+		lastCluster = cluster
+
+		state := string(cluster.State())
+		message := fmt.Sprintf("Cluster %s is '%s'", cluster.Name(), state)
+		if status := cluster.Status(); status != nil && status.Description() != "" {
+			message += ": " + status.Description()
+		}
+		reporter.Report(ctx, message)
+
+		if state == targetState {
+			return NewTextResult(formatClusterWaitResponse(cluster, time.Since(start), ""), nil), nil
+		}
+		if state == string(clustersmgmt.ClusterStateError) {
+			return mcp.NewToolResultError(formatClusterWaitResponse(cluster, time.Since(start),
+				fmt.Sprintf("Cluster entered the error state while waiting for '%s'.", targetState))), nil
+		}
+		if state == string(clustersmgmt.ClusterStateUninstalling) && targetState != clusterStateDeleted {
+			return NewTextResult("", fmt.Errorf("cluster %s is uninstalling and will not reach state '%s'", clusterID, targetState)), nil
+		}
+
+		if time.Now().After(deadline) {
+			return NewTextResult(formatClusterWaitResponse(cluster, time.Since(start),
+				fmt.Sprintf("Timed out after %s waiting for state '%s'. Call wait_for_cluster_state again to keep waiting.", timeout, targetState)), nil), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return NewTextResult("", fmt.Errorf("cancelled while waiting for cluster %s to reach state '%s' (last state: '%s')", clusterID, targetState, state)), nil
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > clusterWaitMaxInterval {
+			interval = clusterWaitMaxInterval
+		}
+	}
+}
+
+// handleCreateClusterAdmin handles the create_cluster_admin tool
+func (s *Server) handleCreateClusterAdmin(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	s.logToolCall("create_cluster_admin", map[string]interface{}{"cluster_id": clusterID})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	cluster, err := client.GetCluster(clusterID)
+	if errorResult := handleOCMError(err, "failed to get cluster"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	credentials, err := client.CreateClusterAdmin(clusterID)
+	if errorResult := handleOCMError(err, "cluster admin creation"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatClusterAdminCreatedResponse(cluster, credentials)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleDeleteClusterAdmin handles the delete_cluster_admin tool
+func (s *Server) handleDeleteClusterAdmin(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	s.logToolCall("delete_cluster_admin", map[string]interface{}{"cluster_id": clusterID})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	err = client.DeleteClusterAdmin(clusterID)
+	if errorResult := handleOCMError(err, "cluster admin deletion"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(fmt.Sprintf("✓ Cluster admin user and identity provider removed from cluster %s. Existing sessions may remain valid until their tokens expire.", clusterID), nil), nil
+}
+
+// handleListIdentityProviders handles the list_identity_providers tool
+func (s *Server) handleListIdentityProviders(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	s.logToolCall("list_identity_providers", map[string]interface{}{"cluster_id": clusterID})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	idps, err := client.GetIdentityProviders(clusterID)
+	if errorResult := handleOCMError(err, "failed to list identity providers"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// HTPasswd users are a sub-resource and are not included in the identity provider list
+	htpasswdUsers := make(map[string][]*clustersmgmt.HTPasswdUser)
+	for _, idp := range idps {
+		if idp.Type() != clustersmgmt.IdentityProviderTypeHtpasswd {
+			continue
+		}
+		users, err := client.GetHTPasswdUsers(clusterID, idp.ID())
+		if errorResult := handleOCMError(err, "failed to list HTPasswd users"); errorResult != nil {
+			return errorResult, nil
+		}
+		htpasswdUsers[idp.ID()] = users
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatIdentityProvidersResponse(clusterID, idps, htpasswdUsers)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleDescribeIdentityProvider handles the describe_identity_provider tool
+func (s *Server) handleDescribeIdentityProvider(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	idpName, ok := args["idp"].(string)
+	if !ok || idpName == "" {
+		return NewTextResult("", errors.New("missing required argument: idp")), nil
+	}
+
+	s.logToolCall("describe_identity_provider", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	idp, err := client.FindIdentityProvider(clusterID, idpName)
+	if errorResult := handleOCMError(err, "failed to get identity provider"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	var users []*clustersmgmt.HTPasswdUser
+	if idp.Type() == clustersmgmt.IdentityProviderTypeHtpasswd {
+		users, err = client.GetHTPasswdUsers(clusterID, idp.ID())
+		if errorResult := handleOCMError(err, "failed to list HTPasswd users"); errorResult != nil {
+			return errorResult, nil
+		}
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatIdentityProviderResponse(clusterID, idp, users)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleDeleteIdentityProvider handles the delete_identity_provider tool
+func (s *Server) handleDeleteIdentityProvider(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return NewTextResult("", errors.New("missing required argument: cluster_id")), nil
+	}
+
+	idpName, ok := args["idp"].(string)
+	if !ok || idpName == "" {
+		return NewTextResult("", errors.New("missing required argument: idp")), nil
+	}
+
+	confirmationToken := mcp.ParseString(ctr, "confirmation_token", "")
+
+	// Never log the confirmation token itself
+	s.logToolCall("delete_identity_provider", map[string]interface{}{
+		"cluster_id":         clusterID,
+		"idp":                idpName,
+		"confirmation_token": confirmationToken != "",
+	})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	idp, err := client.FindIdentityProvider(clusterID, idpName)
+	if errorResult := handleOCMError(err, "failed to get identity provider"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	if idp.Name() == htpasswd.ClusterAdminUsername {
+		return NewTextResult("", errors.New("the cluster-admin identity provider is reserved; use delete_cluster_admin to remove it")), nil
+	}
+
+	// Confirmation tokens are bound to the cluster and identity provider pair
+	resourceID := clusterID + "/" + idp.ID()
+
+	// Phase 1: no token supplied, describe what will be deleted and issue a token
+	if confirmationToken == "" {
+		token, expiresAt, err := s.confirmations.Issue("delete_identity_provider", resourceID)
+		if err != nil {
+			return NewTextResult("", err), nil
+		}
+		return NewTextResult(formatIdentityProviderDeleteConfirmation(clusterID, idp, token, expiresAt), nil), nil
+	}
+
+	// Phase 2: token supplied, validate it before issuing the DELETE
+	if err := s.confirmations.Consume(confirmationToken, "delete_identity_provider", resourceID); err != nil {
+		return NewTextResult("", fmt.Errorf("deletion not confirmed: %w. Call delete_identity_provider without a confirmation_token to request a new one", err)), nil
+	}
+
+	err = client.DeleteIdentityProvider(clusterID, idp.ID())
+	if errorResult := handleOCMError(err, "identity provider deletion"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(fmt.Sprintf("✓ Identity provider '%s' deleted from cluster %s", idp.Name(), clusterID), nil), nil
+}
+
+// requireHTPasswdUserArgs extracts the cluster_id and idp arguments shared by the HTPasswd user tools
+func requireHTPasswdUserArgs(args map[string]interface{}) (string, string, error) {
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return "", "", errors.New("missing required argument: cluster_id")
+	}
+
+	idpName, ok := args["idp"].(string)
+	if !ok || idpName == "" {
+		return "", "", errors.New("missing required argument: idp")
+	}
+	return clusterID, idpName, nil
+}
+
+// errClusterAdminIDPReserved is returned when a tool attempts to modify the users of the reserved cluster-admin identity provider
+var errClusterAdminIDPReserved = errors.New("the cluster-admin identity provider is reserved; use create_cluster_admin and delete_cluster_admin to manage it")
+
+// handleListHTPasswdUsers handles the list_htpasswd_users tool
+func (s *Server) handleListHTPasswdUsers(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, idpName, err := requireHTPasswdUserArgs(args)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	s.logToolCall("list_htpasswd_users", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	idp, err := client.FindHTPasswdIdentityProvider(clusterID, idpName)
+	if errorResult := handleOCMError(err, "failed to get identity provider"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	users, err := client.GetHTPasswdUsers(clusterID, idp.ID())
+	if errorResult := handleOCMError(err, "failed to list HTPasswd users"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatHTPasswdUsersResponse(clusterID, idp, users)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleAddHTPasswdUsers handles the add_htpasswd_users tool
+func (s *Server) handleAddHTPasswdUsers(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, idpName, err := requireHTPasswdUserArgs(args)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	userList, err := htpasswd.ProcessUserInput(args)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	// Never log passwords
+	usernames := make([]string, 0, len(userList))
+	for username := range userList {
+		usernames = append(usernames, username)
+	}
+	s.logToolCall("add_htpasswd_users", map[string]interface{}{
+		"cluster_id": clusterID,
+		"idp":        idpName,
+		"usernames":  usernames,
+	})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	idp, err := client.FindHTPasswdIdentityProvider(clusterID, idpName)
+	if errorResult := handleOCMError(err, "failed to get identity provider"); errorResult != nil {
+		return errorResult, nil
+	}
+	if idp.Name() == htpasswd.ClusterAdminUsername {
+		return NewTextResult("", errClusterAdminIDPReserved), nil
+	}
+
+	added, err := client.AddHTPasswdUsers(clusterID, idp.ID(), userList)
+	if err != nil && len(added) > 0 {
+		return NewTextResult("", fmt.Errorf("added users %s before failing: %w", strings.Join(added, ", "), err)), nil
+	}
+	if errorResult := handleOCMError(err, "adding HTPasswd users"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(fmt.Sprintf("✓ Added %d user(s) to identity provider '%s' on cluster %s: %s", len(added), idp.Name(), clusterID, strings.Join(added, ", ")), nil), nil
+}
+
+// handleRemoveHTPasswdUser handles the remove_htpasswd_user tool
+func (s *Server) handleRemoveHTPasswdUser(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, idpName, err := requireHTPasswdUserArgs(args)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	username, ok := args["username"].(string)
+	if !ok || username == "" {
+		return NewTextResult("", errors.New("missing required argument: username")), nil
+	}
+
+	confirmationToken := mcp.ParseString(ctr, "confirmation_token", "")
+
+	// Never log the confirmation token itself
+	s.logToolCall("remove_htpasswd_user", map[string]interface{}{
+		"cluster_id":         clusterID,
+		"idp":                idpName,
+		"username":           username,
+		"confirmation_token": confirmationToken != "",
+	})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	idp, err := client.FindHTPasswdIdentityProvider(clusterID, idpName)
+	if errorResult := handleOCMError(err, "failed to get identity provider"); errorResult != nil {
+		return errorResult, nil
+	}
+	if idp.Name() == htpasswd.ClusterAdminUsername {
+		return NewTextResult("", errClusterAdminIDPReserved), nil
+	}
+
+	user, err := client.FindHTPasswdUser(clusterID, idp.ID(), username)
+	if errorResult := handleOCMError(err, "failed to get HTPasswd user"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Confirmation tokens are bound to the cluster, identity provider and user
+	resourceID := clusterID + "/" + idp.ID() + "/" + user.ID()
+
+	// Phase 1: no token supplied, describe what will be removed and issue a token
+	if confirmationToken == "" {
+		token, expiresAt, err := s.confirmations.Issue("remove_htpasswd_user", resourceID)
+		if err != nil {
+			return NewTextResult("", err), nil
+		}
+		return NewTextResult(formatHTPasswdUserRemoveConfirmation(clusterID, idp, username, token, expiresAt), nil), nil
+	}
+
+	// Phase 2: token supplied, validate it before issuing the DELETE
+	if err := s.confirmations.Consume(confirmationToken, "remove_htpasswd_user", resourceID); err != nil {
+		return NewTextResult("", fmt.Errorf("removal not confirmed: %w. Call remove_htpasswd_user without a confirmation_token to request a new one", err)), nil
+	}
+
+	err = client.DeleteHTPasswdUser(clusterID, idp.ID(), user.ID())
+	if errorResult := handleOCMError(err, "HTPasswd user removal"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(fmt.Sprintf("✓ User '%s' removed from identity provider '%s' on cluster %s", username, idp.Name(), clusterID), nil), nil
+}
+
+// handleResetHTPasswdUserPassword handles the reset_htpasswd_user_password tool
+func (s *Server) handleResetHTPasswdUserPassword(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	clusterID, idpName, err := requireHTPasswdUserArgs(args)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	username, ok := args["username"].(string)
+	if !ok || username == "" {
+		return NewTextResult("", errors.New("missing required argument: username")), nil
+	}
+
+	password := mcp.ParseString(ctr, "password", "")
+
+	// Never log the password itself
+	s.logToolCall("reset_htpasswd_user_password", map[string]interface{}{
+		"cluster_id": clusterID,
+		"idp":        idpName,
+		"username":   username,
+		"password":   password != "",
+	})
+
+	generated := password == ""
+	if generated {
+		password, err = htpasswd.GenerateValidPassword()
+		if err != nil {
+			return NewTextResult("", err), nil
+		}
+	}
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	idp, err := client.FindHTPasswdIdentityProvider(clusterID, idpName)
+	if errorResult := handleOCMError(err, "failed to get identity provider"); errorResult != nil {
+		return errorResult, nil
+	}
+	if idp.Name() == htpasswd.ClusterAdminUsername {
+		return NewTextResult("", errClusterAdminIDPReserved), nil
+	}
+
+	user, err := client.FindHTPasswdUser(clusterID, idp.ID(), username)
+	if errorResult := handleOCMError(err, "failed to get HTPasswd user"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	err = client.ResetHTPasswdUserPassword(clusterID, idp.ID(), user, password)
+	if errorResult := handleOCMError(err, "password reset"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Format response using MCP layer formatter
+	formattedResponse := formatHTPasswdPasswordResetResponse(clusterID, idp, username, password, generated)
+	return NewTextResult(formattedResponse, nil), nil
+}
+
+// handleListVersions handles the list_versions tool
+func (s *Server) handleListVersions(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	channelGroup := mcp.ParseString(ctr, "channel_group", ocm.DefaultChannelGroup)
+
+	s.logToolCall("list_versions", map[string]interface{}{"channel_group": channelGroup})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	versions, err := client.ListVersions(channelGroup)
+	if errorResult := handleOCMError(err, "version listing"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(formatVersionsResponse(channelGroup, versions, time.Now()), nil), nil
+}
+
+// handleListRegions handles the list_regions tool
+func (s *Server) handleListRegions(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	hcpOnly := mcp.ParseBoolean(ctr, "hcp_only", true)
+
+	s.logToolCall("list_regions", map[string]interface{}{"hcp_only": hcpOnly})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	regions, err := client.ListRegions(hcpOnly)
+	if errorResult := handleOCMError(err, "region listing"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(formatRegionsResponse(regions, hcpOnly), nil), nil
+}
+
+// handleListMachineTypes handles the list_machine_types tool
+func (s *Server) handleListMachineTypes(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	filter := &ocm.MachineTypeFilter{
+		Architecture: mcp.ParseString(ctr, "architecture", ""),
+		Category:     mcp.ParseString(ctr, "category", ""),
+	}
+	var err error
+	for _, field := range []struct {
+		key    string
+		target **int
+	}{
+		{"min_cpu", &filter.MinCPU},
+		{"max_cpu", &filter.MaxCPU},
+		{"min_memory_gib", &filter.MinMemoryGiB},
+		{"max_memory_gib", &filter.MaxMemoryGiB},
+	} {
+		if *field.target, err = parseOptionalInt(args, field.key); err != nil {
+			return NewTextResult("", err), nil
+		}
+	}
+	if err := filter.Validate(); err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	s.logToolCall("list_machine_types", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	machineTypes, err := client.ListMachineTypes(filter)
+	if errorResult := handleOCMError(err, "machine type listing"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(formatMachineTypesResponse(machineTypes), nil), nil
+}
+
+// handleListVPCs handles the list_vpcs tool
+func (s *Server) handleListVPCs(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	awsAccountID, ok := args["aws_account_id"].(string)
+	if !ok || awsAccountID == "" {
+		return NewTextResult("", errors.New("missing required argument: aws_account_id")), nil
+	}
+	roleARN, ok := args["role_arn"].(string)
+	if !ok || roleARN == "" {
+		return NewTextResult("", errors.New("missing required argument: role_arn")), nil
+	}
+	region, ok := args["region"].(string)
+	if !ok || region == "" {
+		return NewTextResult("", errors.New("missing required argument: region")), nil
+	}
+	if err := validation.ValidateAWSAccountID(awsAccountID); err != nil {
+		return NewTextResult("", err), nil
+	}
+	if err := validation.ValidateRoleARN(roleARN, awsAccountID); err != nil {
+		return NewTextResult("", err), nil
+	}
+	if err := validation.ValidateRegion(region); err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	vpcID := mcp.ParseString(ctr, "vpc_id", "")
+	private := mcp.ParseBoolean(ctr, "private", false)
+	zoneCount, err := parseOptionalInt(args, "zone_count")
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	if zoneCount != nil && (*zoneCount < 1 || *zoneCount > 3) {
+		return NewTextResult("", fmt.Errorf("zone_count must be between 1 and 3, got %d", *zoneCount)), nil
+	}
+
+	s.logToolCall("list_vpcs", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	vpcs, err := client.ListVPCs(awsAccountID, roleARN, region)
+	if errorResult := handleOCMError(err, "VPC listing"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	if vpcID != "" {
+		var matching []*clustersmgmt.CloudVPC
+		for _, vpc := range vpcs {
+			if vpc.ID() == vpcID {
+				matching = append(matching, vpc)
+			}
+		}
+		if len(matching) == 0 {
+			return NewTextResult("", fmt.Errorf("VPC '%s' was not found in region %s", vpcID, region)), nil
+		}
+		vpcs = matching
+	}
+
+	count := 0
+	if zoneCount != nil {
+		count = *zoneCount
+	}
+	return NewTextResult(formatVPCsResponse(region, vpcs, private, count), nil), nil
+}
+
+const (
+	// networkVerificationPollInterval is how often OCM is polled while waiting for a network verification
+	networkVerificationPollInterval = 10 * time.Second
+
+	// maxNetworkVerificationTimeout caps how long a single tool call may wait for a network verification
+	maxNetworkVerificationTimeout = 30 * time.Minute
+)
+
+// parseNetworkVerificationWait returns whether and how long to wait for a network verification
+func parseNetworkVerificationWait(ctr mcp.CallToolRequest, defaultWait bool) (bool, time.Duration, error) {
+	wait := mcp.ParseBoolean(ctr, "wait", defaultWait)
+	timeout := time.Duration(mcp.ParseInt(ctr, "timeout_seconds", 600)) * time.Second
+	if timeout <= 0 || timeout > maxNetworkVerificationTimeout {
+		return false, 0, fmt.Errorf("timeout_seconds must be between 1 and %d", int(maxNetworkVerificationTimeout.Seconds()))
+	}
+	return wait, timeout, nil
+}
+
+// waitForNetworkVerification polls the verifications of the subnets until all have finished or the
+// timeout elapses, reporting the number of finished subnets as progress
+func (s *Server) waitForNetworkVerification(ctx context.Context, ctr mcp.CallToolRequest, client *ocm.Client, subnetIDs []string, timeout time.Duration) ([]*clustersmgmt.SubnetNetworkVerification, error) {
+	reporter := s.newProgressReporter(ctr)
+	deadline := time.Now().Add(timeout)
+	for {
+		verifications, err := client.GetNetworkVerifications(subnetIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		finished := 0
+		for _, verification := range verifications {
+			if verification.State() == ocm.NetworkVerificationPassed || verification.State() == ocm.NetworkVerificationFailed {
+				finished++
+			}
+		}
+		reporter.Report(ctx, fmt.Sprintf("%d of %d subnets verified", finished, len(subnetIDs)))
+
+		if ocm.NetworkVerificationDone(verifications) || time.Now().After(deadline) {
+			return verifications, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("cancelled while waiting for the network verification of %s", strings.Join(subnetIDs, ", "))
+		case <-time.After(networkVerificationPollInterval):
+		}
+	}
+}
+
+// handleVerifyNetwork handles the verify_network tool
+func (s *Server) handleVerifyNetwork(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	roleARN, ok := args["role_arn"].(string)
+	if !ok || roleARN == "" {
+		return NewTextResult("", errors.New("missing required argument: role_arn")), nil
+	}
+	region, ok := args["region"].(string)
+	if !ok || region == "" {
+		return NewTextResult("", errors.New("missing required argument: region")), nil
+	}
+	subnetIDs := parseStringArray(args, "subnet_ids")
+	if len(subnetIDs) == 0 {
+		return NewTextResult("", errors.New("missing required argument: subnet_ids")), nil
+	}
+	if err := validation.ValidateRoleARN(roleARN, ""); err != nil {
+		return NewTextResult("", err), nil
+	}
+	if err := validation.ValidateRegion(region); err != nil {
+		return NewTextResult("", err), nil
+	}
+	for _, subnetID := range subnetIDs {
+		if err := validation.ValidateSubnetID(subnetID); err != nil {
+			return NewTextResult("", err), nil
+		}
+	}
+	wait, timeout, err := parseNetworkVerificationWait(ctr, true)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	s.logToolCall("verify_network", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	verifications, err := client.StartNetworkVerification(roleARN, region, subnetIDs)
+	if errorResult := handleOCMError(err, "network verification"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	verification, err := s.networkVerifications.Add(roleARN, region, subnetIDs)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	if wait {
+		verifications, err = s.waitForNetworkVerification(ctx, ctr, client, subnetIDs, timeout)
+		if errorResult := handleOCMError(err, "failed to get network verification"); errorResult != nil {
+			return errorResult, nil
+		}
+	}
+
+	return NewTextResult(formatNetworkVerificationResponse(verification, verifications), nil), nil
+}
+
+// handleGetNetworkVerification handles the get_network_verification tool
+func (s *Server) handleGetNetworkVerification(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	verificationID, ok := args["verification_id"].(string)
+	if !ok || verificationID == "" {
+		return NewTextResult("", errors.New("missing required argument: verification_id")), nil
+	}
+	wait, timeout, err := parseNetworkVerificationWait(ctr, false)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	s.logToolCall("get_network_verification", args)
+
+	verification, err := s.networkVerifications.Get(verificationID)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	var verifications []*clustersmgmt.SubnetNetworkVerification
+	if wait {
+		verifications, err = s.waitForNetworkVerification(ctx, ctr, client, verification.SubnetIDs, timeout)
+	} else {
+		verifications, err = client.GetNetworkVerifications(verification.SubnetIDs)
+	}
+	if errorResult := handleOCMError(err, "failed to get network verification"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(formatNetworkVerificationResponse(verification, verifications), nil), nil
+}
+
+// checkNetworkVerification returns an error unless the verification covers the region and every
+// subnet of the cluster, and the latest OCM verification of each subnet passed
+func (s *Server) checkNetworkVerification(client *ocm.Client, verificationID string, spec *ocm.ClusterCreateSpec) error {
+	verification, err := s.networkVerifications.Get(verificationID)
+	if err != nil {
+		return err
+	}
+	if verification.Region != spec.Region {
+		return fmt.Errorf("network verification %s ran in region %s, not %s", verificationID, verification.Region, spec.Region)
+	}
+	if err := validation.ValidateRoleARN(verification.RoleARN, spec.AWSAccountID); err != nil {
+		return fmt.Errorf("network verification %s ran with an installer role of another AWS account: %w", verificationID, err)
+	}
+	for _, subnetID := range spec.SubnetIDs {
+		if !slices.Contains(verification.SubnetIDs, subnetID) {
+			return fmt.Errorf("network verification %s did not verify subnet '%s'; run verify_network with every subnet of the cluster", verificationID, subnetID)
+		}
+	}
+
+	verifications, err := client.GetNetworkVerifications(spec.SubnetIDs)
+	if err != nil {
+		return err
+	}
+	if err := ocm.ValidateNetworkVerification(verifications, spec.SubnetIDs); err != nil {
+		return fmt.Errorf("network verification %s has not passed: %w", verificationID, err)
+	}
+	return nil
+}
+
+// handleListOIDCConfigs handles the list_oidc_configs tool
+func (s *Server) handleListOIDCConfigs(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logToolCall("list_oidc_configs", ctr.GetArguments())
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	configs, err := client.ListOIDCConfigs()
+	if errorResult := handleOCMError(err, "OIDC configuration listing"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	usage, err := client.GetOIDCConfigUsage()
+	if errorResult := handleOCMError(err, "failed to get clusters"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(formatOIDCConfigsResponse(configs, usage), nil), nil
+}
+
+// handleCreateOIDCConfig handles the create_oidc_config tool
+func (s *Server) handleCreateOIDCConfig(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logToolCall("create_oidc_config", ctr.GetArguments())
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	config, err := client.CreateManagedOIDCConfig()
+	if errorResult := handleOCMError(err, "OIDC configuration creation"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(formatOIDCConfigCreatedResponse(config), nil), nil
+}
+
+// handleDeleteOIDCConfig handles the delete_oidc_config tool
+func (s *Server) handleDeleteOIDCConfig(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	oidcConfigID, ok := args["oidc_config_id"].(string)
+	if !ok || oidcConfigID == "" {
+		return NewTextResult("", errors.New("missing required argument: oidc_config_id")), nil
+	}
+
+	confirmationToken := mcp.ParseString(ctr, "confirmation_token", "")
+
+	// Never log the confirmation token itself
+	s.logToolCall("delete_oidc_config", map[string]interface{}{
+		"oidc_config_id":     oidcConfigID,
+		"confirmation_token": confirmationToken != "",
+	})
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	config, err := client.GetOIDCConfig(oidcConfigID)
+	if errorResult := handleOCMError(err, "failed to get OIDC configuration"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	// Refuse in both phases, a cluster may have been created with the configuration in between
+	usage, err := client.GetOIDCConfigUsage()
+	if errorResult := handleOCMError(err, "failed to get clusters"); errorResult != nil {
+		return errorResult, nil
+	}
+	if clusters := usage[oidcConfigID]; len(clusters) > 0 {
+		names := make([]string, 0, len(clusters))
+		for _, cluster := range clusters {
+			names = append(names, fmt.Sprintf("%s (%s)", cluster.Name(), cluster.ID()))
+		}
+		return NewTextResult("", fmt.Errorf("OIDC configuration %s is still used by: %s. Delete those clusters first", oidcConfigID, strings.Join(names, ", "))), nil
+	}
+
+	// Phase 1: no token supplied, describe what will be deleted and issue a token
+	if confirmationToken == "" {
+		token, expiresAt, err := s.confirmations.Issue("delete_oidc_config", oidcConfigID)
+		if err != nil {
+			return NewTextResult("", err), nil
+		}
+		return NewTextResult(formatOIDCConfigDeleteConfirmation(config, token, expiresAt), nil), nil
+	}
+
+	// Phase 2: token supplied, validate it before issuing the DELETE
+	if err := s.confirmations.Consume(confirmationToken, "delete_oidc_config", oidcConfigID); err != nil {
+		return NewTextResult("", fmt.Errorf("deletion not confirmed: %w. Call delete_oidc_config without a confirmation_token to request a new one", err)), nil
+	}
+
+	err = client.DeleteOIDCConfig(oidcConfigID)
+	if errorResult := handleOCMError(err, "OIDC configuration deletion"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(fmt.Sprintf("✓ OIDC configuration %s deleted. Remove its OIDC provider from the AWS account if no other configuration uses it.", oidcConfigID), nil), nil
+}
+
+// handleValidateAccountRoles handles the validate_account_roles tool
+func (s *Server) handleValidateAccountRoles(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	accountID, ok := args["aws_account_id"].(string)
+	if !ok || accountID == "" {
+		return NewTextResult("", errors.New("missing required argument: aws_account_id")), nil
+	}
+
+	// Missing role ARNs are reported per role in the result
+	spec := &ocm.AccountRolesSpec{
+		AccountID:        accountID,
+		InstallerRoleARN: mcp.ParseString(ctr, "role_arn", ""),
+		SupportRoleARN:   mcp.ParseString(ctr, "support_role_arn", ""),
+		WorkerRoleARN:    mcp.ParseString(ctr, "worker_role_arn", ""),
+		Version:          mcp.ParseString(ctr, "version", ""),
+	}
+
+	s.logToolCall("validate_account_roles", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	check, err := client.ValidateAccountRoles(spec)
+	if errorResult := handleOCMError(err, "account role validation"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	return NewTextResult(formatAccountRolesValidation(check), nil), nil
+}
+
+// handleGetOperatorRoles handles the get_operator_roles tool
+func (s *Server) handleGetOperatorRoles(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ctr.GetArguments()
+
+	spec := &ocm.OperatorRolesSpec{}
+	var ok bool
+	if spec.Prefix, ok = args["operator_role_prefix"].(string); !ok || spec.Prefix == "" {
+		return NewTextResult("", errors.New("missing required argument: operator_role_prefix")), nil
+	}
+	if spec.AccountID, ok = args["aws_account_id"].(string); !ok || spec.AccountID == "" {
+		return NewTextResult("", errors.New("missing required argument: aws_account_id")), nil
+	}
+	oidcConfigID := mcp.ParseString(ctr, "oidc_config_id", "")
+	spec.OIDCIssuerURL = mcp.ParseString(ctr, "oidc_issuer_url", "")
+	if (oidcConfigID == "") == (spec.OIDCIssuerURL == "") {
+		return NewTextResult("", errors.New("exactly one of oidc_config_id or oidc_issuer_url is required")), nil
+	}
+	spec.Version = mcp.ParseString(ctr, "version", "")
+	format := mcp.ParseString(ctr, "format", "summary")
+
+	s.logToolCall("get_operator_roles", args)
+
+	// Get authenticated OCM client
+	client, err := s.getAuthenticatedOCMClient(ctx)
+	if err != nil {
+		return NewTextResult("", errors.New("authentication failed: "+err.Error())), nil
+	}
+	defer client.Close()
+
+	if oidcConfigID != "" {
+		config, err := client.GetOIDCConfig(oidcConfigID)
+		if errorResult := handleOCMError(err, "failed to get OIDC configuration"); errorResult != nil {
+			return errorResult, nil
+		}
+		spec.OIDCIssuerURL = config.IssuerUrl()
+	}
+
+	roles, err := client.GetOperatorRoles(spec)
+	if errorResult := handleOCMError(err, "operator role generation"); errorResult != nil {
+		return errorResult, nil
+	}
+
+	response, err := formatOperatorRolesResponse(spec, roles, format)
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+	return NewTextResult(response, nil), nil
+}
+
 // NewTextResult creates a new MCP CallToolResult
 func NewTextResult(content string, err error) *mcp.CallToolResult {
 	if err != nil {
@@ -0,0 +1,205 @@
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/tiwillia/rosa-mcp-go/pkg/validation"
+)
+
+// accountRolePolicyType is the OCM STS policy type of the account role permission policies
+const accountRolePolicyType = "AccountRole"
+
+// rawVersionRE matches OpenShift release versions such as 4.16.3 or 4.17.0-rc.1
+var rawVersionRE = regexp.MustCompile(`^\d+\.\d+\.\d+(?:-[0-9A-Za-z.]+)?$`)
+
+// AccountRole describes one of the account-wide IAM roles of a ROSA HCP cluster
+type AccountRole struct {
+	// Name is the role type, e.g. Installer
+	Name string
+	// Field is the create_rosa_hcp_cluster parameter that takes the role ARN
+	Field string
+	// Suffix ends the names of the roles created by rosa create account-roles --hosted-cp
+	Suffix string
+	// ClassicSuffix ends the names of the ROSA classic roles of the same type, which HCP clusters cannot use
+	ClassicSuffix string
+	// PolicyID is the OCM STS policy ID of the managed permission policy the role needs
+	PolicyID string
+}
+
+// AccountRoles lists the account roles of a ROSA HCP cluster
+var AccountRoles = []AccountRole{
+	{Name: "Installer", Field: "role_arn", Suffix: "-HCP-ROSA-Installer-Role", ClassicSuffix: "-Installer-Role", PolicyID: "sts_hcp_installer_permission_policy"},
+	{Name: "Support", Field: "support_role_arn", Suffix: "-HCP-ROSA-Support-Role", ClassicSuffix: "-Support-Role", PolicyID: "sts_hcp_support_permission_policy"},
+	{Name: "Worker", Field: "worker_role_arn", Suffix: "-HCP-ROSA-Worker-Role", ClassicSuffix: "-Worker-Role", PolicyID: "sts_hcp_instance_worker_permission_policy"},
+}
+
+// AccountRolesSpec holds the account role ARNs of a ROSA HCP cluster
+type AccountRolesSpec struct {
+	AccountID        string
+	InstallerRoleARN string
+	SupportRoleARN   string
+	WorkerRoleARN    string
+	// Version is the OpenShift version of the cluster; empty skips the version check
+	Version string
+}
+
+// arn returns the ARN the spec holds for a role
+func (s *AccountRolesSpec) arn(role AccountRole) string {
+	switch role.Field {
+	case "role_arn":
+		return s.InstallerRoleARN
+	case "support_role_arn":
+		return s.SupportRoleARN
+	default:
+		return s.WorkerRoleARN
+	}
+}
+
+// AccountRoleCheck is the outcome of checking one account role ARN
+type AccountRoleCheck struct {
+	Role AccountRole
+	ARN  string
+	// Prefix is the account role prefix taken from the role name, when the name follows the convention
+	Prefix string
+	// PolicyARN is the managed policy OCM expects the role to have attached
+	PolicyARN string
+	Problems  []string
+	Warnings  []string
+}
+
+// AccountRolesCheck is the outcome of checking the account roles of a ROSA HCP cluster
+type AccountRolesCheck struct {
+	Spec  *AccountRolesSpec
+	Roles []*AccountRoleCheck
+	// VersionProblem explains why the version cannot be used with ROSA HCP, empty when it can
+	VersionProblem string
+}
+
+// Problems returns the number of problems found
+func (c *AccountRolesCheck) Problems() int {
+	count := 0
+	if c.VersionProblem != "" {
+		count++
+	}
+	for _, role := range c.Roles {
+		count += len(role.Problems)
+	}
+	return count
+}
+
+// ValidateAccountRoles checks the account role ARNs against the ROSA HCP naming conventions, the AWS
+// account and the managed policy metadata published by OCM. When the spec has a version, it must be
+// available for ROSA HCP.
+func (c *Client) ValidateAccountRoles(spec *AccountRolesSpec) (*AccountRolesCheck, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+	if err := validation.ValidateAWSAccountID(spec.AccountID); err != nil {
+		return nil, err
+	}
+
+	glog.V(2).Infof("Retrieving account role policies")
+	policies, err := c.connection.ClustersMgmt().V1().AWSInquiries().
+		STSPolicies().
+		List().Search(fmt.Sprintf("policy_type = '%s'", accountRolePolicyType)).Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list STS policies: %v", err)
+		return nil, HandleOCMError(err)
+	}
+
+	check := CheckAccountRoles(spec, policies.Items().Slice())
+	if spec.Version == "" {
+		return check, nil
+	}
+
+	if !rawVersionRE.MatchString(spec.Version) {
+		check.VersionProblem = fmt.Sprintf("invalid version '%s': expected a release such as 4.16.3", spec.Version)
+		return check, nil
+	}
+	glog.V(2).Infof("Retrieving ROSA HCP version: %s", spec.Version)
+	versions, err := c.connection.ClustersMgmt().V1().Versions().
+		List().
+		Search(fmt.Sprintf("%s AND raw_id = %s", versionSearch(""), quoteSearchValue(spec.Version))).
+		Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list versions: %v", err)
+		return nil, HandleOCMError(err)
+	}
+	if versions.Total() == 0 {
+		check.VersionProblem = fmt.Sprintf("version %s is not available for ROSA HCP clusters. Use list_versions to see the available versions", spec.Version)
+	}
+	return check, nil
+}
+
+// CheckAccountRoles checks each account role ARN of the spec. policies are the OCM account role
+// policies, used to find the managed policy each role needs.
+func CheckAccountRoles(spec *AccountRolesSpec, policies []*cmv1.AWSSTSPolicy) *AccountRolesCheck {
+	policyARNs := make(map[string]string, len(policies))
+	for _, policy := range policies {
+		policyARNs[policy.ID()] = policy.ARN()
+	}
+
+	check := &AccountRolesCheck{Spec: spec}
+	seen := make(map[string]string)
+	for _, role := range AccountRoles {
+		roleCheck := checkAccountRole(role, spec.arn(role), spec.AccountID)
+		if roleCheck.ARN != "" {
+			if other, ok := seen[roleCheck.ARN]; ok {
+				roleCheck.Problems = append(roleCheck.Problems, fmt.Sprintf("same role as %s; every account role must be a different role", other))
+			}
+			seen[roleCheck.ARN] = role.Field
+		}
+
+		roleCheck.PolicyARN = policyARNs[role.PolicyID]
+		if roleCheck.PolicyARN == "" {
+			roleCheck.Warnings = append(roleCheck.Warnings, fmt.Sprintf("OCM has no managed policy '%s' to check the role against", role.PolicyID))
+		}
+		check.Roles = append(check.Roles, roleCheck)
+	}
+
+	// Roles created together share a prefix, a different one usually means a role of another set
+	installer := check.Roles[0]
+	for _, roleCheck := range check.Roles[1:] {
+		if installer.Prefix != "" && roleCheck.Prefix != "" && roleCheck.Prefix != installer.Prefix {
+			roleCheck.Warnings = append(roleCheck.Warnings, fmt.Sprintf("prefix '%s' differs from the installer role prefix '%s'", roleCheck.Prefix, installer.Prefix))
+		}
+	}
+	return check
+}
+
+// checkAccountRole checks the format, AWS account and name of one account role ARN
+func checkAccountRole(role AccountRole, arn, accountID string) *AccountRoleCheck {
+	check := &AccountRoleCheck{Role: role, ARN: arn}
+	if arn == "" {
+		check.Problems = append(check.Problems, "missing role ARN")
+		return check
+	}
+	if err := validation.ValidateRoleARN(arn, accountID); err != nil {
+		check.Problems = append(check.Problems, err.Error())
+		return check
+	}
+
+	name := arn[strings.LastIndex(arn, "/")+1:]
+	if prefix, ok := strings.CutSuffix(name, role.Suffix); ok && prefix != "" {
+		check.Prefix = prefix
+		return check
+	}
+	for _, other := range AccountRoles {
+		if other.Name != role.Name && strings.HasSuffix(name, other.Suffix) {
+			check.Problems = append(check.Problems, fmt.Sprintf("'%s' is a %s role, %s needs the %s role", name, other.Name, role.Field, role.Name))
+			return check
+		}
+	}
+	if strings.HasSuffix(name, role.ClassicSuffix) && !strings.Contains(name, "-HCP-ROSA-") {
+		check.Problems = append(check.Problems, fmt.Sprintf("'%s' is a ROSA classic account role; ROSA HCP clusters need the roles created with 'rosa create account-roles --hosted-cp', named <prefix>%s", name, role.Suffix))
+		return check
+	}
+	check.Warnings = append(check.Warnings, fmt.Sprintf("'%s' does not follow the <prefix>%s naming convention; make sure it has the ROSA HCP managed policy attached", name, role.Suffix))
+	return check
+}
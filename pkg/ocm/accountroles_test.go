@@ -0,0 +1,138 @@
+package ocm
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAccountRolesSpec returns the account roles created by rosa create account-roles --hosted-cp
+func testAccountRolesSpec() *AccountRolesSpec {
+	return &AccountRolesSpec{
+		AccountID:        "123456789012",
+		InstallerRoleARN: "arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Installer-Role",
+		SupportRoleARN:   "arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Support-Role",
+		WorkerRoleARN:    "arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Worker-Role",
+	}
+}
+
+// testAccountRolePolicies builds the managed account role policies as returned by OCM
+func testAccountRolePolicies(t *testing.T) []*cmv1.AWSSTSPolicy {
+	var policies []*cmv1.AWSSTSPolicy
+	for _, role := range AccountRoles {
+		policy, err := cmv1.NewAWSSTSPolicy().
+			ID(role.PolicyID).
+			ARN("arn:aws:iam::aws:policy/service-role/ROSA" + role.Name + "Policy").
+			Type(accountRolePolicyType).
+			Build()
+		assert.NoError(t, err)
+		policies = append(policies, policy)
+	}
+	return policies
+}
+
+func TestCheckAccountRoles(t *testing.T) {
+	check := CheckAccountRoles(testAccountRolesSpec(), testAccountRolePolicies(t))
+	assert.Equal(t, 0, check.Problems())
+	assert.Len(t, check.Roles, 3)
+	for _, role := range check.Roles {
+		assert.Empty(t, role.Warnings)
+		assert.Equal(t, "ManagedOpenShift", role.Prefix)
+	}
+	assert.Equal(t, "arn:aws:iam::aws:policy/service-role/ROSAWorkerPolicy", check.Roles[2].PolicyARN)
+
+	// Missing policy metadata is reported, but does not fail the check
+	check = CheckAccountRoles(testAccountRolesSpec(), testAccountRolePolicies(t)[:2])
+	assert.Equal(t, 0, check.Problems())
+	assert.Len(t, check.Roles[2].Warnings, 1)
+}
+
+func TestCheckAccountRolesProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(spec *AccountRolesSpec)
+		role     int
+		problem  string
+		warning  string
+		problems int
+	}{
+		{
+			name:     "missing",
+			modify:   func(spec *AccountRolesSpec) { spec.SupportRoleARN = "" },
+			role:     1,
+			problem:  "missing role ARN",
+			problems: 1,
+		},
+		{
+			name: "other account",
+			modify: func(spec *AccountRolesSpec) {
+				spec.WorkerRoleARN = "arn:aws:iam::210987654321:role/ManagedOpenShift-HCP-ROSA-Worker-Role"
+			},
+			role:     2,
+			problem:  "210987654321",
+			problems: 1,
+		},
+		{
+			name: "classic role",
+			modify: func(spec *AccountRolesSpec) {
+				spec.InstallerRoleARN = "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"
+			},
+			role:     0,
+			problem:  "ROSA classic account role",
+			problems: 1,
+		},
+		{
+			name: "swapped roles",
+			modify: func(spec *AccountRolesSpec) {
+				spec.SupportRoleARN, spec.WorkerRoleARN = spec.WorkerRoleARN, spec.SupportRoleARN
+			},
+			role:     1,
+			problem:  "is a Worker role, support_role_arn needs the Support role",
+			problems: 2,
+		},
+		{
+			name:     "custom name",
+			modify:   func(spec *AccountRolesSpec) { spec.WorkerRoleARN = "arn:aws:iam::123456789012:role/custom" },
+			role:     2,
+			warning:  "naming convention",
+			problems: 0,
+		},
+		{
+			name: "duplicate custom role",
+			modify: func(spec *AccountRolesSpec) {
+				spec.SupportRoleARN = "arn:aws:iam::123456789012:role/custom"
+				spec.WorkerRoleARN = "arn:aws:iam::123456789012:role/custom"
+			},
+			role:     2,
+			problem:  "same role as support_role_arn",
+			problems: 1,
+		},
+		{
+			name: "different prefix",
+			modify: func(spec *AccountRolesSpec) {
+				spec.SupportRoleARN = "arn:aws:iam::123456789012:role/Other-HCP-ROSA-Support-Role"
+			},
+			role:     1,
+			warning:  "differs from the installer role prefix 'ManagedOpenShift'",
+			problems: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := testAccountRolesSpec()
+			tt.modify(spec)
+			check := CheckAccountRoles(spec, testAccountRolePolicies(t))
+			assert.Equal(t, tt.problems, check.Problems())
+			role := check.Roles[tt.role]
+			if tt.problem != "" {
+				assert.Len(t, role.Problems, 1)
+				assert.Contains(t, role.Problems[0], tt.problem)
+			}
+			if tt.warning != "" {
+				assert.Len(t, role.Warnings, 1)
+				assert.Contains(t, role.Warnings[0], tt.warning)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ocm
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/golang/glog"
@@ -79,6 +80,7 @@ type OCMError struct {
 	Code        string
 	Reason      string
 	OperationID string
+	Status      int
 }
 
 func (e *OCMError) Error() string {
@@ -113,6 +115,24 @@ func IsAccessTokenExpiredError(err error) bool {
 	return false
 }
 
+// IsNotFoundError checks if the error indicates that the requested OCM resource does not exist
+func IsNotFoundError(err error) bool {
+	if ocmErr, ok := err.(*OCMError); ok {
+		return ocmErr.Status == http.StatusNotFound
+	}
+	return false
+}
+
+// IsRequestRejectedError checks if OCM rejected a request because of its content, as opposed to
+// failing to authenticate it or to process it
+func IsRequestRejectedError(err error) bool {
+	if ocmErr, ok := err.(*OCMError); ok {
+		return ocmErr.Status >= http.StatusBadRequest && ocmErr.Status < http.StatusInternalServerError &&
+			ocmErr.Status != http.StatusUnauthorized && !IsAccessTokenExpiredError(ocmErr)
+	}
+	return false
+}
+
 // HandleOCMError converts an OCM SDK error to our error type with enhanced token handling
 func HandleOCMError(err error) error {
 	if err == nil {
@@ -125,6 +145,7 @@ func HandleOCMError(err error) error {
 			Code:        ocmErr.Code(),
 			Reason:      ocmErr.Reason(),
 			OperationID: ocmErr.OperationID(),
+			Status:      ocmErr.Status(),
 		}
 		
 		// Add helpful message for expired access tokens
@@ -157,18 +178,23 @@ func (c *Client) GetCurrentAccount() (*accountsmgmt.Account, error) {
 	return account, nil
 }
 
-// GetClusters returns a list of clusters filtered by state
-func (c *Client) GetClusters(state string) ([]*clustersmgmt.Cluster, error) {
+// GetClusters returns the clusters that match the search, in the search order
+func (c *Client) GetClusters(search *ClusterSearch) ([]*clustersmgmt.Cluster, error) {
 	if c.connection == nil {
 		return nil, fmt.Errorf("client not authenticated")
 	}
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
 
-	glog.V(2).Infof("Retrieving clusters with state filter: %s", state)
+	query := search.Query()
+	glog.V(2).Infof("Retrieving clusters with search: %s", query)
 	request := c.connection.ClustersMgmt().V1().Clusters().List()
-
-	// Add state filter if provided
-	if state != "" {
-		request = request.Search(fmt.Sprintf("state = '%s'", state))
+	if query != "" {
+		request = request.Search(query)
+	}
+	if order := search.Order(); order != "" {
+		request = request.Order(order)
 	}
 
 	response, err := request.Send()
@@ -205,56 +231,37 @@ func (c *Client) GetCluster(clusterID string) (*clustersmgmt.Cluster, error) {
 	return cluster, nil
 }
 
-// CreateROSAHCPCluster creates a new ROSA HCP cluster
-func (c *Client) CreateROSAHCPCluster(
-	clusterName, awsAccountID, billingAccountID, roleArn,
-	operatorRolePrefix, oidcConfigID, supportRoleArn, workerRoleArn, rosaCreatorArn string,
-	subnetIDs []string, availabilityZones []string, region string,
-	multiArchEnabled bool,
-) (*clustersmgmt.Cluster, error) {
+// CreateROSAHCPCluster creates a ROSA HCP cluster from a create spec
+func (c *Client) CreateROSAHCPCluster(spec *ClusterCreateSpec) (*clustersmgmt.Cluster, error) {
 	if c.connection == nil {
 		return nil, fmt.Errorf("client not authenticated")
 	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
 
-	glog.V(2).Infof("Creating ROSA HCP cluster: %s in region %s", clusterName, region)
-
-	// Build ROSA HCP cluster payload following the example structure
-	clusterBuilder := clustersmgmt.NewCluster().
-		Name(clusterName).
-		Product(clustersmgmt.NewProduct().ID("rosa")).
-		Region(clustersmgmt.NewCloudRegion().ID(region)).
-		AWS(clustersmgmt.NewAWS().
-			AccountID(awsAccountID).
-			BillingAccountID(billingAccountID).
-			STS(clustersmgmt.NewSTS().
-				AutoMode(true).
-				RoleARN(roleArn).
-				OperatorRolePrefix(operatorRolePrefix).
-				SupportRoleARN(supportRoleArn).
-				InstanceIAMRoles(clustersmgmt.NewInstanceIAMRoles().
-					WorkerRoleARN(workerRoleArn)).
-				OidcConfig(clustersmgmt.NewOidcConfig().ID(oidcConfigID))).
-			SubnetIDs(subnetIDs...)).
-		Nodes(clustersmgmt.NewClusterNodes().
-			AvailabilityZones(availabilityZones...)).
-		MultiAZ(true).
-		MultiArchEnabled(multiArchEnabled).
-		Properties(map[string]string{
-			"rosa_creator_arn": rosaCreatorArn,
-		}).
-		CCS(clustersmgmt.NewCCS().Enabled(true)).
-		Hypershift(clustersmgmt.NewHypershift().Enabled(true)).
-		BillingModel("marketplace-aws")
-
-	cluster, err := clusterBuilder.Build()
+	// Check subnet visibility and CIDRs against the requested API visibility and machine network before creating anything
+	if spec.needsSubnetLookup() {
+		subnets, err := c.GetSubnets(spec.AWSAccountID, spec.InstallerRoleARN, spec.Region, spec.SubnetIDs)
+		if err != nil {
+			return nil, err
+		}
+		if err := spec.ValidateSubnets(subnets); err != nil {
+			return nil, err
+		}
+	}
+
+	glog.V(2).Infof("Creating ROSA HCP cluster: %s in region %s", spec.Name, spec.Region)
+
+	cluster, err := spec.build()
 	if err != nil {
-		glog.Errorf("Failed to build cluster payload for %s: %v", clusterName, err)
+		glog.Errorf("Failed to build cluster payload for %s: %v", spec.Name, err)
 		return nil, fmt.Errorf("failed to build cluster payload: %w", err)
 	}
 
 	response, err := c.connection.ClustersMgmt().V1().Clusters().Add().Body(cluster).Send()
 	if err != nil {
-		glog.Errorf("Failed to create cluster %s: %v", clusterName, err)
+		glog.Errorf("Failed to create cluster %s: %v", spec.Name, err)
 		return nil, HandleOCMError(err)
 	}
 
@@ -262,3 +269,87 @@ func (c *Client) CreateROSAHCPCluster(
 	glog.Infof("Successfully initiated cluster creation: %s (ID: %s)", createdCluster.Name(), createdCluster.ID())
 	return createdCluster, nil
 }
+
+// ClusterDryRunResult is the outcome of validating a create spec without creating the cluster
+type ClusterDryRunResult struct {
+	// Cluster is the payload sent to OCM, with the defaults of the spec filled in. OCM returns no
+	// cluster for a dry run, so the defaults OCM applies on creation are not included.
+	Cluster *clustersmgmt.Cluster
+	// ValidationError is the first problem found, or nil when the cluster would be accepted
+	ValidationError error
+}
+
+// Passed reports whether OCM would accept the cluster
+func (r *ClusterDryRunResult) Passed() bool {
+	return r.ValidationError == nil
+}
+
+// DryRunROSAHCPCluster runs the checks of CreateROSAHCPCluster and sends the payload to OCM in
+// dry-run mode, so that the cluster is validated but not created. Validation failures are
+// reported in the result; only errors that prevent the validation from running are returned.
+func (c *Client) DryRunROSAHCPCluster(spec *ClusterCreateSpec) (*ClusterDryRunResult, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	cluster, err := spec.build()
+	if err != nil {
+		glog.Errorf("Failed to build cluster payload for %s: %v", spec.Name, err)
+		return nil, fmt.Errorf("failed to build cluster payload: %w", err)
+	}
+	result := &ClusterDryRunResult{Cluster: cluster}
+
+	if err := spec.Validate(); err != nil {
+		result.ValidationError = err
+		return result, nil
+	}
+
+	if spec.needsSubnetLookup() {
+		subnets, err := c.GetSubnets(spec.AWSAccountID, spec.InstallerRoleARN, spec.Region, spec.SubnetIDs)
+		if err != nil {
+			if !IsRequestRejectedError(err) {
+				return nil, err
+			}
+			result.ValidationError = fmt.Errorf("failed to look up subnets: %w", err)
+			return result, nil
+		}
+		if err := spec.ValidateSubnets(subnets); err != nil {
+			result.ValidationError = err
+			return result, nil
+		}
+	}
+
+	glog.V(2).Infof("Dry-run creating ROSA HCP cluster: %s in region %s", spec.Name, spec.Region)
+
+	_, err = c.connection.ClustersMgmt().V1().Clusters().Add().Parameter("dryRun", true).Body(cluster).Send()
+	if err != nil {
+		err = HandleOCMError(err)
+		if !IsRequestRejectedError(err) {
+			glog.Errorf("Failed to dry-run cluster %s: %v", spec.Name, err)
+			return nil, err
+		}
+		glog.Infof("Dry-run rejected cluster %s: %v", spec.Name, err)
+		result.ValidationError = err
+		return result, nil
+	}
+
+	glog.Infof("Dry-run accepted cluster %s", spec.Name)
+	return result, nil
+}
+
+// DeleteCluster requests deletion of a cluster by ID
+func (c *Client) DeleteCluster(clusterID string) error {
+	if c.connection == nil {
+		return fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Deleting cluster: %s", clusterID)
+	_, err := c.connection.ClustersMgmt().V1().Clusters().Cluster(clusterID).Delete().Send()
+	if err != nil {
+		glog.Errorf("Failed to delete cluster %s: %v", clusterID, err)
+		return HandleOCMError(err)
+	}
+
+	glog.Infof("Successfully initiated cluster deletion: %s", clusterID)
+	return nil
+}
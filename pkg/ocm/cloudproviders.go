@@ -0,0 +1,189 @@
+package ocm
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	// awsCloudProviderID is the OCM cloud provider of ROSA clusters
+	awsCloudProviderID = "aws"
+	// bytesPerGiB converts OCM memory values, which are in bytes, to GiB
+	bytesPerGiB = 1 << 30
+)
+
+// ValidArchitectures lists the CPU architectures of machine types. arm64 compute nodes require a
+// cluster created with multi_arch_enabled.
+var ValidArchitectures = []string{string(cmv1.ProcessorTypeAMD64), string(cmv1.ProcessorTypeARM64)}
+
+// ValidMachineTypeCategories lists the categories of machine types
+var ValidMachineTypeCategories = []string{
+	string(cmv1.MachineTypeCategoryGeneralPurpose),
+	string(cmv1.MachineTypeCategoryComputeOptimized),
+	string(cmv1.MachineTypeCategoryMemoryOptimized),
+	string(cmv1.MachineTypeCategoryAcceleratedComputing),
+}
+
+// ListRegions returns the enabled AWS regions, sorted by ID. When hcpOnly is set only the
+// regions that support ROSA HCP clusters are returned.
+func (c *Client) ListRegions(hcpOnly bool) ([]*cmv1.CloudRegion, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Retrieving AWS regions (HCP only: %t)", hcpOnly)
+	response, err := c.connection.ClustersMgmt().V1().
+		CloudProviders().CloudProvider(awsCloudProviderID).
+		Regions().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list AWS regions: %v", err)
+		return nil, HandleOCMError(err)
+	}
+
+	regions := FilterRegions(response.Items().Slice(), hcpOnly)
+	glog.V(2).Infof("Retrieved %d regions", len(regions))
+	return regions, nil
+}
+
+// FilterRegions returns the enabled regions, optionally only those supporting ROSA HCP, sorted by ID
+func FilterRegions(regions []*cmv1.CloudRegion, hcpOnly bool) []*cmv1.CloudRegion {
+	var filtered []*cmv1.CloudRegion
+	for _, region := range regions {
+		if !region.Enabled() || (hcpOnly && !region.SupportsHypershift()) {
+			continue
+		}
+		filtered = append(filtered, region)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].ID() < filtered[j].ID()
+	})
+	return filtered
+}
+
+// MachineTypeFilter selects machine types. Empty and nil fields do not filter.
+type MachineTypeFilter struct {
+	Architecture string
+	Category     string
+	MinCPU       *int
+	MaxCPU       *int
+	MinMemoryGiB *int
+	MaxMemoryGiB *int
+}
+
+// Validate checks the filter values
+func (f *MachineTypeFilter) Validate() error {
+	if f.Architecture != "" && !slices.Contains(ValidArchitectures, f.Architecture) {
+		return fmt.Errorf("invalid architecture '%s': must be one of %s", f.Architecture, strings.Join(ValidArchitectures, ", "))
+	}
+	if f.Category != "" && !slices.Contains(ValidMachineTypeCategories, f.Category) {
+		return fmt.Errorf("invalid category '%s': must be one of %s", f.Category, strings.Join(ValidMachineTypeCategories, ", "))
+	}
+	if f.MinCPU != nil && f.MaxCPU != nil && *f.MinCPU > *f.MaxCPU {
+		return fmt.Errorf("min_cpu (%d) cannot be greater than max_cpu (%d)", *f.MinCPU, *f.MaxCPU)
+	}
+	if f.MinMemoryGiB != nil && f.MaxMemoryGiB != nil && *f.MinMemoryGiB > *f.MaxMemoryGiB {
+		return fmt.Errorf("min_memory_gib (%d) cannot be greater than max_memory_gib (%d)", *f.MinMemoryGiB, *f.MaxMemoryGiB)
+	}
+	return nil
+}
+
+// matches reports whether a machine type passes the filter
+func (f *MachineTypeFilter) matches(machineType *cmv1.MachineType) bool {
+	if f.Architecture != "" && string(machineType.Architecture()) != f.Architecture {
+		return false
+	}
+	if f.Category != "" && string(machineType.Category()) != f.Category {
+		return false
+	}
+	cpu := MachineTypeCPU(machineType)
+	if (f.MinCPU != nil && cpu < float64(*f.MinCPU)) || (f.MaxCPU != nil && cpu > float64(*f.MaxCPU)) {
+		return false
+	}
+	memory := MachineTypeMemoryGiB(machineType)
+	if (f.MinMemoryGiB != nil && memory < float64(*f.MinMemoryGiB)) || (f.MaxMemoryGiB != nil && memory > float64(*f.MaxMemoryGiB)) {
+		return false
+	}
+	return true
+}
+
+// ListMachineTypes returns the AWS machine types that match the filter, sorted by category, CPU, memory and ID
+func (c *Client) ListMachineTypes(filter *MachineTypeFilter) ([]*cmv1.MachineType, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	glog.V(2).Infof("Retrieving AWS machine types")
+	response, err := c.connection.ClustersMgmt().V1().
+		MachineTypes().
+		List().
+		Search(fmt.Sprintf("cloud_provider.id = '%s'", awsCloudProviderID)).
+		Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list machine types: %v", err)
+		return nil, HandleOCMError(err)
+	}
+
+	machineTypes := FilterMachineTypes(response.Items().Slice(), filter)
+	glog.V(2).Infof("Retrieved %d machine types", len(machineTypes))
+	return machineTypes, nil
+}
+
+// FilterMachineTypes returns the machine types that match the filter, sorted by category, CPU, memory and ID
+func FilterMachineTypes(machineTypes []*cmv1.MachineType, filter *MachineTypeFilter) []*cmv1.MachineType {
+	var filtered []*cmv1.MachineType
+	for _, machineType := range machineTypes {
+		if filter.matches(machineType) {
+			filtered = append(filtered, machineType)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		if a.Category() != b.Category() {
+			return a.Category() < b.Category()
+		}
+		if MachineTypeCPU(a) != MachineTypeCPU(b) {
+			return MachineTypeCPU(a) < MachineTypeCPU(b)
+		}
+		if MachineTypeMemoryGiB(a) != MachineTypeMemoryGiB(b) {
+			return MachineTypeMemoryGiB(a) < MachineTypeMemoryGiB(b)
+		}
+		return a.ID() < b.ID()
+	})
+	return filtered
+}
+
+// MachineTypeCPU returns the number of vCPUs of a machine type
+func MachineTypeCPU(machineType *cmv1.MachineType) float64 {
+	if cpu := machineType.CPU(); cpu != nil {
+		return cpu.Value()
+	}
+	return 0
+}
+
+// MachineTypeMemoryGiB returns the memory of a machine type in GiB
+func MachineTypeMemoryGiB(machineType *cmv1.MachineType) float64 {
+	memory := machineType.Memory()
+	if memory == nil {
+		return 0
+	}
+	switch strings.ToLower(memory.Unit()) {
+	case "gib", "gb":
+		return memory.Value()
+	case "mib", "mb":
+		return math.Round(memory.Value()/1024*100) / 100
+	default:
+		return math.Round(memory.Value()/bytesPerGiB*100) / 100
+	}
+}
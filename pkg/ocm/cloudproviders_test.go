@@ -0,0 +1,96 @@
+package ocm
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testMachineType builds a machine type as returned by OCM, with memory in bytes
+func testMachineType(t *testing.T, id string, category cmv1.MachineTypeCategory, arch cmv1.ProcessorType, cpu, memoryGiB float64) *cmv1.MachineType {
+	machineType, err := cmv1.NewMachineType().
+		ID(id).
+		Category(category).
+		Architecture(arch).
+		CPU(cmv1.NewValue().Unit("vCPU").Value(cpu)).
+		Memory(cmv1.NewValue().Unit("B").Value(memoryGiB * bytesPerGiB)).
+		Build()
+	assert.NoError(t, err)
+	return machineType
+}
+
+func TestFilterRegions(t *testing.T) {
+	var regions []*cmv1.CloudRegion
+	for _, r := range []struct {
+		id         string
+		enabled    bool
+		hypershift bool
+	}{
+		{"us-west-2", true, true},
+		{"us-east-1", true, true},
+		{"eu-south-2", true, false},
+		{"ap-east-1", false, true},
+	} {
+		region, err := cmv1.NewCloudRegion().ID(r.id).Enabled(r.enabled).SupportsHypershift(r.hypershift).Build()
+		assert.NoError(t, err)
+		regions = append(regions, region)
+	}
+
+	ids := func(regions []*cmv1.CloudRegion) []string {
+		var result []string
+		for _, region := range regions {
+			result = append(result, region.ID())
+		}
+		return result
+	}
+	assert.Equal(t, []string{"us-east-1", "us-west-2"}, ids(FilterRegions(regions, true)))
+	assert.Equal(t, []string{"eu-south-2", "us-east-1", "us-west-2"}, ids(FilterRegions(regions, false)))
+}
+
+func TestFilterMachineTypes(t *testing.T) {
+	machineTypes := []*cmv1.MachineType{
+		testMachineType(t, "m5.2xlarge", cmv1.MachineTypeCategoryGeneralPurpose, cmv1.ProcessorTypeAMD64, 8, 32),
+		testMachineType(t, "m5.xlarge", cmv1.MachineTypeCategoryGeneralPurpose, cmv1.ProcessorTypeAMD64, 4, 16),
+		testMachineType(t, "m6g.xlarge", cmv1.MachineTypeCategoryGeneralPurpose, cmv1.ProcessorTypeARM64, 4, 16),
+		testMachineType(t, "c5.2xlarge", cmv1.MachineTypeCategoryComputeOptimized, cmv1.ProcessorTypeAMD64, 8, 16),
+		testMachineType(t, "r5.xlarge", cmv1.MachineTypeCategoryMemoryOptimized, cmv1.ProcessorTypeAMD64, 4, 32),
+	}
+
+	ids := func(machineTypes []*cmv1.MachineType) []string {
+		var result []string
+		for _, machineType := range machineTypes {
+			result = append(result, machineType.ID())
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		filter   *MachineTypeFilter
+		expected []string
+	}{
+		{name: "no filter", filter: &MachineTypeFilter{}, expected: []string{"c5.2xlarge", "m5.xlarge", "m6g.xlarge", "m5.2xlarge", "r5.xlarge"}},
+		{name: "arm64", filter: &MachineTypeFilter{Architecture: "arm64"}, expected: []string{"m6g.xlarge"}},
+		{name: "category", filter: &MachineTypeFilter{Category: "general_purpose", Architecture: "amd64"}, expected: []string{"m5.xlarge", "m5.2xlarge"}},
+		{name: "cpu range", filter: &MachineTypeFilter{MinCPU: intPtr(8), MaxCPU: intPtr(8)}, expected: []string{"c5.2xlarge", "m5.2xlarge"}},
+		{name: "memory", filter: &MachineTypeFilter{MinMemoryGiB: intPtr(32)}, expected: []string{"m5.2xlarge", "r5.xlarge"}},
+		{name: "max memory", filter: &MachineTypeFilter{MaxMemoryGiB: intPtr(16), MaxCPU: intPtr(4)}, expected: []string{"m5.xlarge", "m6g.xlarge"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.filter.Validate())
+			assert.Equal(t, tt.expected, ids(FilterMachineTypes(machineTypes, tt.filter)))
+		})
+	}
+
+	assert.Equal(t, 16.0, MachineTypeMemoryGiB(machineTypes[1]))
+	assert.Equal(t, 4.0, MachineTypeCPU(machineTypes[1]))
+}
+
+func TestMachineTypeFilterValidate(t *testing.T) {
+	assert.Error(t, (&MachineTypeFilter{Architecture: "x86"}).Validate())
+	assert.Error(t, (&MachineTypeFilter{Category: "storage_optimized"}).Validate())
+	assert.Error(t, (&MachineTypeFilter{MinCPU: intPtr(8), MaxCPU: intPtr(4)}).Validate())
+	assert.Error(t, (&MachineTypeFilter{MinMemoryGiB: intPtr(64), MaxMemoryGiB: intPtr(32)}).Validate())
+}
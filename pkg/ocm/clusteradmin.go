@@ -0,0 +1,144 @@
+package ocm
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	idputils "github.com/openshift-online/ocm-common/pkg/idp/utils"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/tiwillia/rosa-mcp-go/pkg/htpasswd"
+)
+
+// ClusterAdminsGroup is the cluster group granting cluster-admin privileges
+const ClusterAdminsGroup = "cluster-admins"
+
+// ClusterAdminCredentials holds the credentials of a newly created cluster admin user
+type ClusterAdminCredentials struct {
+	IdentityProvider *cmv1.IdentityProvider
+	Username         string
+	Password         string
+}
+
+// findClusterAdminIdentityProvider returns the reserved cluster-admin IDP, or nil if it does not exist
+func (c *Client) findClusterAdminIdentityProvider(clusterID string) (*cmv1.IdentityProvider, error) {
+	idps, err := c.GetIdentityProviders(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	for _, idp := range idps {
+		if idp.Name() == htpasswd.ClusterAdminUsername {
+			return idp, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateClusterAdmin creates the reserved cluster-admin HTPasswd identity provider with a
+// generated password and adds the user to the cluster-admins group, like 'rosa create admin'
+func (c *Client) CreateClusterAdmin(clusterID string) (*ClusterAdminCredentials, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	existing, err := c.findClusterAdminIdentityProvider(clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing identity providers: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("cluster admin already exists on cluster %s; delete it before creating a new one", clusterID)
+	}
+
+	password, err := htpasswd.GenerateValidPassword()
+	if err != nil {
+		return nil, err
+	}
+	hashedPwd, err := idputils.GenerateHTPasswdCompatibleHash(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash cluster admin password: %w", err)
+	}
+
+	idp, err := cmv1.NewIdentityProvider().
+		Type(cmv1.IdentityProviderTypeHtpasswd).
+		Name(htpasswd.ClusterAdminUsername).
+		MappingMethod(cmv1.IdentityProviderMappingMethodClaim).
+		Htpasswd(cmv1.NewHTPasswdIdentityProvider().
+			Users(cmv1.NewHTPasswdUserList().Items(
+				cmv1.NewHTPasswdUser().
+					Username(htpasswd.ClusterAdminUsername).
+					HashedPassword(hashedPwd)))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build identity provider: %w", err)
+	}
+
+	glog.V(2).Infof("Creating cluster admin identity provider for cluster %s", clusterID)
+	createdIdp, err := c.CreateIdentityProvider(clusterID, idp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster admin identity provider: %w", err)
+	}
+
+	user, err := cmv1.NewUser().ID(htpasswd.ClusterAdminUsername).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cluster admin user: %w", err)
+	}
+
+	_, err = c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		Groups().Group(ClusterAdminsGroup).
+		Users().
+		Add().Body(user).
+		Send()
+	if err != nil {
+		addErr := HandleOCMError(err)
+		// Roll back so the cluster is not left with a user lacking the expected privileges
+		if deleteErr := c.DeleteIdentityProvider(clusterID, createdIdp.ID()); deleteErr != nil {
+			glog.Errorf("Failed to roll back cluster admin identity provider on cluster %s: %v", clusterID, deleteErr)
+		}
+		return nil, fmt.Errorf("failed to add cluster admin to group '%s': %w", ClusterAdminsGroup, addErr)
+	}
+
+	glog.Infof("Successfully created cluster admin on cluster %s", clusterID)
+	return &ClusterAdminCredentials{
+		IdentityProvider: createdIdp,
+		Username:         htpasswd.ClusterAdminUsername,
+		Password:         password,
+	}, nil
+}
+
+// DeleteClusterAdmin removes the cluster-admin user from the cluster-admins group and
+// deletes the reserved cluster-admin identity provider, like 'rosa delete admin'
+func (c *Client) DeleteClusterAdmin(clusterID string) error {
+	if c.connection == nil {
+		return fmt.Errorf("client not authenticated")
+	}
+
+	idp, err := c.findClusterAdminIdentityProvider(clusterID)
+	if err != nil {
+		return fmt.Errorf("failed to check existing identity providers: %w", err)
+	}
+
+	glog.V(2).Infof("Removing cluster admin from group %s on cluster %s", ClusterAdminsGroup, clusterID)
+	_, err = c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		Groups().Group(ClusterAdminsGroup).
+		Users().User(htpasswd.ClusterAdminUsername).
+		Delete().
+		Send()
+	if err != nil {
+		if handledErr := HandleOCMError(err); !IsNotFoundError(handledErr) {
+			return fmt.Errorf("failed to remove cluster admin from group '%s': %w", ClusterAdminsGroup, handledErr)
+		}
+	}
+
+	if idp == nil {
+		return fmt.Errorf("cluster admin identity provider does not exist on cluster %s", clusterID)
+	}
+
+	if err := c.DeleteIdentityProvider(clusterID, idp.ID()); err != nil {
+		return fmt.Errorf("failed to delete cluster admin identity provider: %w", err)
+	}
+
+	glog.Infof("Successfully deleted cluster admin on cluster %s", clusterID)
+	return nil
+}
@@ -0,0 +1,144 @@
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// ValidClusterStates lists the cluster states OCM reports
+var ValidClusterStates = []string{
+	string(cmv1.ClusterStateError),
+	string(cmv1.ClusterStateHibernating),
+	string(cmv1.ClusterStateInstalling),
+	string(cmv1.ClusterStatePending),
+	string(cmv1.ClusterStatePoweringDown),
+	string(cmv1.ClusterStateReady),
+	string(cmv1.ClusterStateResuming),
+	string(cmv1.ClusterStateUninstalling),
+	string(cmv1.ClusterStateUnknown),
+	string(cmv1.ClusterStateUpdating),
+	string(cmv1.ClusterStateValidating),
+	string(cmv1.ClusterStateWaiting),
+}
+
+// clusterSortFields maps the sort keys of a cluster search to OCM cluster fields
+var clusterSortFields = map[string]string{
+	"name":    "name",
+	"created": "creation_timestamp",
+	"state":   "state",
+	"region":  "region.id",
+	"version": "openshift_version",
+}
+
+// ValidClusterSortFields lists the sort keys of a cluster search
+var ValidClusterSortFields = sortedKeys(clusterSortFields)
+
+// minorVersionRE matches a major.minor version such as 4.16
+var minorVersionRE = regexp.MustCompile(`^\d+\.\d+$`)
+
+// ClusterSearch filters and orders a cluster listing. Empty and nil fields do not filter.
+type ClusterSearch struct {
+	// NameOrID matches clusters whose name or ID contains the value, ignoring case
+	NameOrID string
+	// States matches clusters in any of the states
+	States  []string
+	Region  string
+	Product string
+	// Version matches a release such as 4.16.3, or every release of a minor version such as 4.16
+	Version    string
+	Hypershift *bool
+	// Creator matches clusters whose creator ARN contains the value, e.g. an account ID or user name
+	Creator       string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// SortBy is one of ValidClusterSortFields; empty keeps the OCM order
+	SortBy     string
+	Descending bool
+}
+
+// Validate checks the search values that must come from a fixed set
+func (s *ClusterSearch) Validate() error {
+	for _, state := range s.States {
+		if !slices.Contains(ValidClusterStates, state) {
+			return fmt.Errorf("invalid state '%s': must be one of %s", state, strings.Join(ValidClusterStates, ", "))
+		}
+	}
+	if s.SortBy != "" && clusterSortFields[s.SortBy] == "" {
+		return fmt.Errorf("invalid sort field '%s': must be one of %s", s.SortBy, strings.Join(ValidClusterSortFields, ", "))
+	}
+	if s.CreatedAfter != nil && s.CreatedBefore != nil && s.CreatedAfter.After(*s.CreatedBefore) {
+		return fmt.Errorf("created_after (%s) cannot be later than created_before (%s)",
+			s.CreatedAfter.Format(time.RFC3339), s.CreatedBefore.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Query returns the OCM search expression for the filters, or "" when nothing is filtered.
+// Every value is quoted, so input cannot change the structure of the query.
+func (s *ClusterSearch) Query() string {
+	var terms []string
+	if s.NameOrID != "" {
+		pattern := quoteSearchValue("%" + escapeLikePattern(s.NameOrID) + "%")
+		terms = append(terms, fmt.Sprintf("(name ilike %s or id ilike %s)", pattern, pattern))
+	}
+	if len(s.States) > 0 {
+		states := make([]string, 0, len(s.States))
+		for _, state := range s.States {
+			states = append(states, quoteSearchValue(state))
+		}
+		terms = append(terms, fmt.Sprintf("state in (%s)", strings.Join(states, ", ")))
+	}
+	if s.Region != "" {
+		terms = append(terms, fmt.Sprintf("region.id = %s", quoteSearchValue(s.Region)))
+	}
+	if s.Product != "" {
+		terms = append(terms, fmt.Sprintf("product.id = %s", quoteSearchValue(s.Product)))
+	}
+	if s.Version != "" {
+		if minorVersionRE.MatchString(s.Version) {
+			terms = append(terms, fmt.Sprintf("openshift_version like %s", quoteSearchValue(escapeLikePattern(s.Version)+".%")))
+		} else {
+			terms = append(terms, fmt.Sprintf("openshift_version = %s", quoteSearchValue(s.Version)))
+		}
+	}
+	if s.Hypershift != nil {
+		terms = append(terms, fmt.Sprintf("hypershift.enabled = '%t'", *s.Hypershift))
+	}
+	if s.Creator != "" {
+		terms = append(terms, fmt.Sprintf("properties.%s like %s", CreatorARNProperty, quoteSearchValue("%"+escapeLikePattern(s.Creator)+"%")))
+	}
+	if s.CreatedAfter != nil {
+		terms = append(terms, fmt.Sprintf("creation_timestamp >= '%s'", s.CreatedAfter.UTC().Format(time.RFC3339Nano)))
+	}
+	if s.CreatedBefore != nil {
+		terms = append(terms, fmt.Sprintf("creation_timestamp <= '%s'", s.CreatedBefore.UTC().Format(time.RFC3339Nano)))
+	}
+	return strings.Join(terms, " and ")
+}
+
+// Order returns the OCM order expression, or "" to keep the OCM order
+func (s *ClusterSearch) Order() string {
+	field := clusterSortFields[s.SortBy]
+	if field == "" {
+		return ""
+	}
+	if s.Descending {
+		return field + " desc"
+	}
+	return field + " asc"
+}
+
+// quoteSearchValue returns value as an OCM search string literal, doubling single quotes
+func quoteSearchValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// escapeLikePattern escapes the wildcards of a like pattern so value only matches itself
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,98 @@
+package ocm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterSearchQuery(t *testing.T) {
+	hypershift := true
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 6, 30, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	endOfDay := time.Date(2024, 6, 30, 23, 59, 59, 999999999, time.UTC)
+
+	tests := []struct {
+		name     string
+		search   ClusterSearch
+		expected string
+	}{
+		{
+			name:     "no filters",
+			search:   ClusterSearch{},
+			expected: "",
+		},
+		{
+			name:     "name or ID",
+			search:   ClusterSearch{NameOrID: "prod"},
+			expected: "(name ilike '%prod%' or id ilike '%prod%')",
+		},
+		{
+			name:     "states",
+			search:   ClusterSearch{States: []string{"ready", "installing"}},
+			expected: "state in ('ready', 'installing')",
+		},
+		{
+			name:     "minor version",
+			search:   ClusterSearch{Version: "4.16"},
+			expected: "openshift_version like '4.16.%'",
+		},
+		{
+			name:     "release version",
+			search:   ClusterSearch{Version: "4.16.3"},
+			expected: "openshift_version = '4.16.3'",
+		},
+		{
+			name: "combined",
+			search: ClusterSearch{
+				Region:        "us-east-1",
+				Product:       "rosa",
+				Hypershift:    &hypershift,
+				Creator:       "123456789012",
+				CreatedAfter:  &after,
+				CreatedBefore: &before,
+			},
+			expected: "region.id = 'us-east-1' and product.id = 'rosa' and hypershift.enabled = 'true' and " +
+				"properties.rosa_creator_arn like '%123456789012%' and " +
+				"creation_timestamp >= '2024-01-01T00:00:00Z' and creation_timestamp <= '2024-06-30T10:00:00Z'",
+		},
+		{
+			name:     "fractional upper bound",
+			search:   ClusterSearch{CreatedBefore: &endOfDay},
+			expected: "creation_timestamp <= '2024-06-30T23:59:59.999999999Z'",
+		},
+		{
+			name:     "quotes cannot end the literal",
+			search:   ClusterSearch{Region: "x' or name = 'y"},
+			expected: "region.id = 'x'' or name = ''y'",
+		},
+		{
+			name:     "wildcards match literally",
+			search:   ClusterSearch{NameOrID: `a%b_c\`},
+			expected: `(name ilike '%a\%b\_c\\%' or id ilike '%a\%b\_c\\%')`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.search.Validate())
+			assert.Equal(t, tt.expected, tt.search.Query())
+		})
+	}
+}
+
+func TestClusterSearchOrder(t *testing.T) {
+	assert.Equal(t, "", (&ClusterSearch{}).Order())
+	assert.Equal(t, "name asc", (&ClusterSearch{SortBy: "name"}).Order())
+	assert.Equal(t, "creation_timestamp desc", (&ClusterSearch{SortBy: "created", Descending: true}).Order())
+	assert.Equal(t, []string{"created", "name", "region", "state", "version"}, ValidClusterSortFields)
+}
+
+func TestClusterSearchValidate(t *testing.T) {
+	after := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.ErrorContains(t, (&ClusterSearch{States: []string{"ready", "ready' or '1'='1"}}).Validate(), "invalid state")
+	assert.ErrorContains(t, (&ClusterSearch{SortBy: "id; drop"}).Validate(), "invalid sort field")
+	assert.ErrorContains(t, (&ClusterSearch{CreatedAfter: &after, CreatedBefore: &before}).Validate(), "cannot be later")
+}
@@ -0,0 +1,411 @@
+package ocm
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/tiwillia/rosa-mcp-go/pkg/validation"
+)
+
+const (
+	// DefaultComputeMachineType is the instance type of the default compute nodes
+	DefaultComputeMachineType = "m5.xlarge"
+	// DefaultComputeReplicas is the number of default compute nodes when neither replicas nor autoscaling is set
+	DefaultComputeReplicas = 2
+	// DefaultBillingModel is the billing model of new ROSA HCP clusters
+	DefaultBillingModel = string(cmv1.BillingModelMarketplaceAWS)
+	// minHCPComputeReplicas is the minimum number of compute nodes of a ROSA HCP cluster
+	minHCPComputeReplicas = 2
+)
+
+// kmsKeyARNRE matches AWS KMS key ARNs, capturing the region. Multi-region keys use the mrk- key ID prefix.
+var kmsKeyARNRE = regexp.MustCompile(`^arn:aws(?:-cn|-us-gov)?:kms:([a-z]{2}(?:-gov)?-[a-z]+-\d):\d{12}:key/(?:mrk-[0-9a-f]{32}|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// validBillingModels lists the billing models accepted for ROSA HCP clusters
+var validBillingModels = []string{string(cmv1.BillingModelMarketplaceAWS), string(cmv1.BillingModelStandard)}
+
+// ClusterCreateSpec describes a ROSA HCP cluster to create. The optional fields fall back to
+// the defaults used by the rosa CLI when they are nil or empty.
+type ClusterCreateSpec struct {
+	Name               string
+	Region             string
+	AWSAccountID       string
+	BillingAccountID   string
+	InstallerRoleARN   string
+	SupportRoleARN     string
+	WorkerRoleARN      string
+	OperatorRolePrefix string
+	OIDCConfigID       string
+	CreatorARN         string
+	SubnetIDs          []string
+	AvailabilityZones  []string
+	MultiArchEnabled   bool
+
+	Version            string
+	ChannelGroup       string
+	ComputeMachineType string
+	Scaling            *NodePoolScaling
+	MultiAZ            *bool
+	BillingModel       string
+	STSAutoMode        *bool
+
+	// Private makes the API server reachable only from inside the VPC through AWS PrivateLink
+	Private               bool
+	HTTPProxy             string
+	HTTPSProxy            string
+	NoProxy               []string
+	AdditionalTrustBundle string
+
+	// KMSKeyARN encrypts the EBS volumes of the nodes with a customer-managed key
+	KMSKeyARN string
+	// EtcdEncryptionKMSKeyARN enables etcd encryption with a customer-managed key
+	EtcdEncryptionKMSKeyARN string
+
+	// Network overrides the default machine, service and pod networks
+	Network *ClusterNetwork
+
+	// AWSTags are applied to every AWS resource the cluster creates
+	AWSTags map[string]string
+	// Properties are extra OCM cluster properties, added next to the creator ARN
+	Properties map[string]string
+}
+
+// Validate checks the whole spec locally, without contacting OCM or AWS. The returned error is a
+// validation.Issues listing every problem found.
+func (s *ClusterCreateSpec) Validate() error {
+	issues := validation.ValidateClusterConfig(validation.ClusterConfig{
+		Name:               s.Name,
+		Region:             s.Region,
+		AWSAccountID:       s.AWSAccountID,
+		BillingAccountID:   s.BillingAccountID,
+		InstallerRoleARN:   s.InstallerRoleARN,
+		SupportRoleARN:     s.SupportRoleARN,
+		WorkerRoleARN:      s.WorkerRoleARN,
+		OperatorRolePrefix: s.OperatorRolePrefix,
+		CreatorARN:         s.CreatorARN,
+		SubnetIDs:          s.SubnetIDs,
+		AvailabilityZones:  s.AvailabilityZones,
+		Private:            s.Private,
+	})
+	if err := s.validateSettings(); err != nil {
+		issues = append(issues, validation.Issue{Message: err.Error()})
+	}
+	if len(issues) > 0 {
+		return issues
+	}
+	return nil
+}
+
+// validateSettings checks the optional settings of the spec
+func (s *ClusterCreateSpec) validateSettings() error {
+	if s.Version != "" {
+		if _, err := MinorVersion(s.Version); err != nil {
+			return fmt.Errorf("invalid version: %w", err)
+		}
+	}
+	if s.ChannelGroup != "" {
+		if !slices.Contains(ValidChannelGroups, s.ChannelGroup) {
+			return fmt.Errorf("invalid channel group '%s': must be one of %s", s.ChannelGroup, strings.Join(ValidChannelGroups, ", "))
+		}
+		if s.ChannelGroup != DefaultChannelGroup && s.Version == "" {
+			return fmt.Errorf("channel group '%s' requires a version; use list_versions to find one", s.ChannelGroup)
+		}
+	}
+
+	if s.Scaling != nil {
+		if err := s.Scaling.Validate(); err != nil {
+			return err
+		}
+		if s.Scaling.Replicas != nil && *s.Scaling.Replicas < minHCPComputeReplicas {
+			return fmt.Errorf("replicas must be at least %d for a ROSA HCP cluster, got %d", minHCPComputeReplicas, *s.Scaling.Replicas)
+		}
+		if s.Scaling.MinReplicas != nil && *s.Scaling.MinReplicas < minHCPComputeReplicas {
+			return fmt.Errorf("min_replicas must be at least %d for a ROSA HCP cluster, got %d", minHCPComputeReplicas, *s.Scaling.MinReplicas)
+		}
+	}
+
+	if s.BillingModel != "" && !slices.Contains(validBillingModels, s.BillingModel) {
+		return fmt.Errorf("invalid billing model '%s': must be one of %s", s.BillingModel, strings.Join(validBillingModels, ", "))
+	}
+
+	if err := s.validateProxy(); err != nil {
+		return err
+	}
+
+	if err := s.Network.Validate(s.multiAZ(), s.maxComputeNodes()); err != nil {
+		return err
+	}
+
+	if err := ValidateAWSTags(s.AWSTags); err != nil {
+		return err
+	}
+	if err := ValidateClusterProperties(s.Properties); err != nil {
+		return err
+	}
+
+	if s.KMSKeyARN != "" {
+		if err := ValidateKMSKeyARN("kms_key_arn", s.KMSKeyARN, s.Region); err != nil {
+			return err
+		}
+	}
+	if s.EtcdEncryptionKMSKeyARN != "" {
+		if err := ValidateKMSKeyARN("etcd_encryption_kms_key_arn", s.EtcdEncryptionKMSKeyARN, s.Region); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// multiAZ reports whether the cluster spreads its nodes over multiple availability zones
+func (s *ClusterCreateSpec) multiAZ() bool {
+	if s.MultiAZ != nil {
+		return *s.MultiAZ
+	}
+	return true
+}
+
+// maxComputeNodes returns the largest number of compute nodes the cluster is created with
+func (s *ClusterCreateSpec) maxComputeNodes() int {
+	switch {
+	case s.Scaling != nil && s.Scaling.MaxReplicas != nil:
+		return *s.Scaling.MaxReplicas
+	case s.Scaling != nil && s.Scaling.Replicas != nil:
+		return *s.Scaling.Replicas
+	default:
+		return DefaultComputeReplicas
+	}
+}
+
+// ValidateKMSKeyARN checks that arn is a KMS key ARN in the cluster's region
+func ValidateKMSKeyARN(field, arn, region string) error {
+	match := kmsKeyARNRE.FindStringSubmatch(arn)
+	if match == nil {
+		return fmt.Errorf("invalid %s '%s': expected arn:aws:kms:<region>:<account-id>:key/<key-id>", field, arn)
+	}
+	if region != "" && match[1] != region {
+		return fmt.Errorf("invalid %s '%s': the key is in region %s but the cluster is in %s", field, arn, match[1], region)
+	}
+	return nil
+}
+
+// validateProxy checks the cluster-wide proxy settings and additional trust bundle
+func (s *ClusterCreateSpec) validateProxy() error {
+	if s.HTTPProxy != "" {
+		if err := validateProxyURL("http_proxy", s.HTTPProxy, "http"); err != nil {
+			return err
+		}
+	}
+	if s.HTTPSProxy != "" {
+		if err := validateProxyURL("https_proxy", s.HTTPSProxy, "http", "https"); err != nil {
+			return err
+		}
+	}
+	if len(s.NoProxy) > 0 {
+		if s.HTTPProxy == "" && s.HTTPSProxy == "" {
+			return fmt.Errorf("no_proxy can only be set together with http_proxy or https_proxy")
+		}
+		for _, entry := range s.NoProxy {
+			if entry == "" || strings.ContainsAny(entry, " ,") {
+				return fmt.Errorf("invalid no_proxy entry '%s': expected a domain, IP address or CIDR", entry)
+			}
+			if entry == "*" {
+				return fmt.Errorf("no_proxy '*' is not supported; list the domains that bypass the proxy instead")
+			}
+		}
+	}
+	if s.AdditionalTrustBundle != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(s.AdditionalTrustBundle)) {
+			return fmt.Errorf("invalid additional trust bundle: expected one or more PEM encoded certificates")
+		}
+	}
+	return nil
+}
+
+// validateProxyURL checks that a proxy URL is absolute and uses one of the allowed schemes
+func validateProxyURL(field, value string, schemes ...string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("invalid %s '%s': expected a URL such as http://proxy.example.com:3128", field, RedactProxyURL(value))
+	}
+	if !slices.Contains(schemes, parsed.Scheme) {
+		return fmt.Errorf("invalid %s '%s': scheme must be %s", field, RedactProxyURL(value), strings.Join(schemes, " or "))
+	}
+	return nil
+}
+
+// RedactProxyURL returns a proxy URL without its user:password@ part, for logs and display
+func RedactProxyURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		// Drop everything up to the last '@' so credentials in malformed URLs are not shown either
+		if i := strings.LastIndex(value, "@"); i >= 0 {
+			return value[i+1:]
+		}
+		return value
+	}
+	parsed.User = nil
+	return parsed.String()
+}
+
+// ValidateSubnets checks the spec against the details of its subnets: private clusters must
+// only use private subnets, public clusters need a public subnet for their load balancers, and
+// the machine network must contain every subnet.
+func (s *ClusterCreateSpec) ValidateSubnets(subnets []*cmv1.Subnetwork) error {
+	found := make(map[string]*cmv1.Subnetwork, len(subnets))
+	for _, subnet := range subnets {
+		found[subnet.SubnetID()] = subnet
+	}
+
+	var public []string
+	for _, subnetID := range s.SubnetIDs {
+		subnet, ok := found[subnetID]
+		if !ok {
+			return fmt.Errorf("subnet '%s' was not found in region %s", subnetID, s.Region)
+		}
+		if subnet.Public() {
+			public = append(public, subnetID)
+		}
+	}
+
+	if s.Private && len(public) > 0 {
+		return fmt.Errorf("private clusters must only use private subnets; public subnets: %s", strings.Join(public, ", "))
+	}
+	if !s.Private && len(public) == 0 {
+		return fmt.Errorf("public clusters need at least one public subnet for their load balancers; set private to true or add a public subnet")
+	}
+	return s.Network.ValidateSubnetCIDRs(subnets)
+}
+
+// needsSubnetLookup reports whether the spec has subnet checks that OCM does not make itself:
+// the subnet visibility of private clusters and the subnets of a custom machine network.
+// Other specs skip the VPC inquiry, so they do not depend on it succeeding.
+func (s *ClusterCreateSpec) needsSubnetLookup() bool {
+	return s.Private || (s.Network != nil && s.Network.MachineCIDR != "")
+}
+
+// versionID returns the OCM version ID for a raw OpenShift version of a channel group, e.g.
+// openshift-v4.16.3 for 4.16.3 in stable and openshift-v4.17.0-rc.1-candidate for 4.17.0-rc.1 in candidate
+func versionID(version, channelGroup string) string {
+	if strings.HasPrefix(version, "openshift-") {
+		return version
+	}
+	id := "openshift-v" + strings.TrimPrefix(version, "v")
+	if channelGroup != "" && channelGroup != DefaultChannelGroup {
+		id += "-" + channelGroup
+	}
+	return id
+}
+
+// build creates the OCM cluster payload described by the spec
+func (s *ClusterCreateSpec) build() (*cmv1.Cluster, error) {
+	autoMode := true
+	if s.STSAutoMode != nil {
+		autoMode = *s.STSAutoMode
+	}
+	billingModel := DefaultBillingModel
+	if s.BillingModel != "" {
+		billingModel = s.BillingModel
+	}
+	apiListening := cmv1.ListeningMethodExternal
+	if s.Private {
+		apiListening = cmv1.ListeningMethodInternal
+	}
+	machineType := DefaultComputeMachineType
+	if s.ComputeMachineType != "" {
+		machineType = s.ComputeMachineType
+	}
+
+	nodes := cmv1.NewClusterNodes().
+		AvailabilityZones(s.AvailabilityZones...).
+		ComputeMachineType(cmv1.NewMachineType().ID(machineType))
+	switch {
+	case s.Scaling != nil && s.Scaling.Replicas == nil:
+		nodes = nodes.AutoscaleCompute(cmv1.NewMachinePoolAutoscaling().
+			MinReplicas(*s.Scaling.MinReplicas).
+			MaxReplicas(*s.Scaling.MaxReplicas))
+	case s.Scaling != nil:
+		nodes = nodes.Compute(*s.Scaling.Replicas)
+	default:
+		nodes = nodes.Compute(DefaultComputeReplicas)
+	}
+
+	awsBuilder := cmv1.NewAWS().
+		AccountID(s.AWSAccountID).
+		BillingAccountID(s.BillingAccountID).
+		STS(cmv1.NewSTS().
+			AutoMode(autoMode).
+			RoleARN(s.InstallerRoleARN).
+			OperatorRolePrefix(s.OperatorRolePrefix).
+			SupportRoleARN(s.SupportRoleARN).
+			InstanceIAMRoles(cmv1.NewInstanceIAMRoles().
+				WorkerRoleARN(s.WorkerRoleARN)).
+			OidcConfig(cmv1.NewOidcConfig().ID(s.OIDCConfigID))).
+		SubnetIDs(s.SubnetIDs...).
+		PrivateLink(s.Private)
+	if s.KMSKeyARN != "" {
+		awsBuilder = awsBuilder.KMSKeyArn(s.KMSKeyARN)
+	}
+	if s.EtcdEncryptionKMSKeyARN != "" {
+		awsBuilder = awsBuilder.EtcdEncryption(cmv1.NewAwsEtcdEncryption().KMSKeyARN(s.EtcdEncryptionKMSKeyARN))
+	}
+	if len(s.AWSTags) > 0 {
+		awsBuilder = awsBuilder.Tags(s.AWSTags)
+	}
+
+	properties := make(map[string]string, len(s.Properties)+1)
+	for key, value := range s.Properties {
+		properties[key] = value
+	}
+	properties[CreatorARNProperty] = s.CreatorARN
+
+	// Build ROSA HCP cluster payload following the example structure
+	clusterBuilder := cmv1.NewCluster().
+		Name(s.Name).
+		Product(cmv1.NewProduct().ID("rosa")).
+		Region(cmv1.NewCloudRegion().ID(s.Region)).
+		AWS(awsBuilder).
+		API(cmv1.NewClusterAPI().Listening(apiListening)).
+		EtcdEncryption(s.EtcdEncryptionKMSKeyARN != "").
+		Nodes(nodes).
+		MultiAZ(s.multiAZ()).
+		MultiArchEnabled(s.MultiArchEnabled).
+		Properties(properties).
+		CCS(cmv1.NewCCS().Enabled(true)).
+		Hypershift(cmv1.NewHypershift().Enabled(true)).
+		BillingModel(cmv1.BillingModel(billingModel))
+
+	if s.Version != "" {
+		channelGroup := DefaultChannelGroup
+		if s.ChannelGroup != "" {
+			channelGroup = s.ChannelGroup
+		}
+		clusterBuilder = clusterBuilder.Version(cmv1.NewVersion().ID(versionID(s.Version, channelGroup)).ChannelGroup(channelGroup))
+	}
+
+	if s.HTTPProxy != "" || s.HTTPSProxy != "" {
+		proxy := cmv1.NewProxy()
+		if s.HTTPProxy != "" {
+			proxy = proxy.HTTPProxy(s.HTTPProxy)
+		}
+		if s.HTTPSProxy != "" {
+			proxy = proxy.HTTPSProxy(s.HTTPSProxy)
+		}
+		if len(s.NoProxy) > 0 {
+			proxy = proxy.NoProxy(strings.Join(s.NoProxy, ","))
+		}
+		clusterBuilder = clusterBuilder.Proxy(proxy)
+	}
+	if s.AdditionalTrustBundle != "" {
+		clusterBuilder = clusterBuilder.AdditionalTrustBundle(s.AdditionalTrustBundle)
+	}
+	if network := s.Network.build(); network != nil {
+		clusterBuilder = clusterBuilder.Network(network)
+	}
+
+	return clusterBuilder.Build()
+}
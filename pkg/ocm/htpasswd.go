@@ -2,7 +2,10 @@ package ocm
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/golang/glog"
 	idputils "github.com/openshift-online/ocm-common/pkg/idp/utils"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	
@@ -35,6 +38,239 @@ func (c *Client) CreateIdentityProvider(clusterID string, idp *cmv1.IdentityProv
 	return response.Body(), nil
 }
 
+// GetHTPasswdUsers returns the users of an HTPasswd identity provider
+func (c *Client) GetHTPasswdUsers(clusterID, idpID string) ([]*cmv1.HTPasswdUser, error) {
+	response, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		HtpasswdUsers().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		return nil, HandleOCMError(err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// DeleteIdentityProvider deletes an identity provider from a cluster
+func (c *Client) DeleteIdentityProvider(clusterID, idpID string) error {
+	_, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().
+		IdentityProvider(idpID).
+		Delete().
+		Send()
+	if err != nil {
+		return HandleOCMError(err)
+	}
+	return nil
+}
+
+// buildHTPasswdUser validates the credentials using ROSA CLI validation and returns a user
+// carrying only the hashed password
+func buildHTPasswdUser(username, password string) (*cmv1.HTPasswdUserBuilder, error) {
+	if err := htpasswd.ValidateUserCredentials(username, password); err != nil {
+		return nil, fmt.Errorf("invalid user credentials for '%s': %w", username, err)
+	}
+
+	// Always hash passwords using ROSA CLI method
+	hashedPwd, err := idputils.GenerateHTPasswdCompatibleHash(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password for user '%s': %w", username, err)
+	}
+
+	return cmv1.NewHTPasswdUser().
+		Username(username).
+		HashedPassword(hashedPwd), nil
+}
+
+// FindHTPasswdUser returns the user of an HTPasswd identity provider with the given username
+func (c *Client) FindHTPasswdUser(clusterID, idpID, username string) (*cmv1.HTPasswdUser, error) {
+	users, err := c.GetHTPasswdUsers(clusterID, idpID)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.Username() == username {
+			return user, nil
+		}
+	}
+	return nil, fmt.Errorf("user '%s' not found in identity provider %s", username, idpID)
+}
+
+// AddHTPasswdUsers adds users to an existing HTPasswd identity provider. All credentials are
+// validated and checked against the existing users before any user is added. The usernames
+// added before a failure are returned along with the error.
+func (c *Client) AddHTPasswdUsers(clusterID, idpID string, userList map[string]string) ([]string, error) {
+	existingUsers, err := c.GetHTPasswdUsers(clusterID, idpID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(existingUsers))
+	for _, user := range existingUsers {
+		existing[user.Username()] = true
+	}
+
+	usernames := make([]string, 0, len(userList))
+	for username := range userList {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	users := make([]*cmv1.HTPasswdUser, 0, len(usernames))
+	for _, username := range usernames {
+		if existing[username] {
+			return nil, fmt.Errorf("user '%s' already exists in identity provider %s", username, idpID)
+		}
+		userBuilder, err := buildHTPasswdUser(username, userList[username])
+		if err != nil {
+			return nil, err
+		}
+		user, err := userBuilder.Build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build user '%s': %w", username, err)
+		}
+		users = append(users, user)
+	}
+
+	added := make([]string, 0, len(users))
+	for _, user := range users {
+		if err := c.addHTPasswdUser(clusterID, idpID, user); err != nil {
+			return added, err
+		}
+		added = append(added, user.Username())
+	}
+	return added, nil
+}
+
+// addHTPasswdUser adds a user with an already hashed password to an HTPasswd identity provider
+func (c *Client) addHTPasswdUser(clusterID, idpID string, user *cmv1.HTPasswdUser) error {
+	_, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		HtpasswdUsers().
+		Add().Body(user).
+		Send()
+	if err != nil {
+		return HandleOCMError(err)
+	}
+	return nil
+}
+
+// updateHTPasswdUser replaces the stored user, including its hashed password
+func (c *Client) updateHTPasswdUser(clusterID, idpID, userID string, user *cmv1.HTPasswdUser) error {
+	_, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		HtpasswdUsers().HtpasswdUser(userID).
+		Update().Body(user).
+		Send()
+	if err != nil {
+		return HandleOCMError(err)
+	}
+	return nil
+}
+
+// ResetHTPasswdUserPassword replaces the password of an existing HTPasswd user
+func (c *Client) ResetHTPasswdUserPassword(clusterID, idpID string, user *cmv1.HTPasswdUser, password string) error {
+	userBuilder, err := buildHTPasswdUser(user.Username(), password)
+	if err != nil {
+		return err
+	}
+	body, err := userBuilder.ID(user.ID()).Build()
+	if err != nil {
+		return fmt.Errorf("failed to build user '%s': %w", user.Username(), err)
+	}
+
+	return c.updateHTPasswdUser(clusterID, idpID, user.ID(), body)
+}
+
+// DeleteHTPasswdUser removes a user from an HTPasswd identity provider
+func (c *Client) DeleteHTPasswdUser(clusterID, idpID, userID string) error {
+	_, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		HtpasswdUsers().HtpasswdUser(userID).
+		Delete().
+		Send()
+	if err != nil {
+		return HandleOCMError(err)
+	}
+	return nil
+}
+
+// HTPasswdOverwriteMode controls how setup overwrites an existing HTPasswd identity provider with the same name
+type HTPasswdOverwriteMode string
+
+const (
+	// HTPasswdOverwriteReplaceUsers keeps the provider and makes its users exactly the given list
+	HTPasswdOverwriteReplaceUsers HTPasswdOverwriteMode = "replace_users"
+	// HTPasswdOverwriteMerge keeps the provider, adds new users and resets the passwords of existing ones
+	HTPasswdOverwriteMerge HTPasswdOverwriteMode = "merge"
+	// HTPasswdOverwriteRecreate deletes the provider and creates it again, allowing the mapping method to change.
+	// The given users must include every existing user, so the provider can be restored if recreation fails.
+	HTPasswdOverwriteRecreate HTPasswdOverwriteMode = "recreate"
+)
+
+// ParseHTPasswdOverwriteMode validates an overwrite mode, defaulting to replace_users
+func ParseHTPasswdOverwriteMode(mode string) (HTPasswdOverwriteMode, error) {
+	switch HTPasswdOverwriteMode(mode) {
+	case "":
+		return HTPasswdOverwriteReplaceUsers, nil
+	case HTPasswdOverwriteReplaceUsers, HTPasswdOverwriteMerge, HTPasswdOverwriteRecreate:
+		return HTPasswdOverwriteMode(mode), nil
+	default:
+		return "", fmt.Errorf("invalid overwrite mode '%s': must be one of replace_users, merge, recreate", mode)
+	}
+}
+
+// HTPasswdSetupResult describes the outcome of setting up an HTPasswd identity provider
+type HTPasswdSetupResult struct {
+	IdentityProvider *cmv1.IdentityProvider
+	// Overwritten is true when an existing provider with the same name was overwritten
+	Overwritten bool
+	Mode        HTPasswdOverwriteMode
+	Added       []string
+	Updated     []string
+	Removed     []string
+}
+
+// progress describes the changes applied so far, for errors raised part way through an overwrite
+func (r *HTPasswdSetupResult) progress() string {
+	return fmt.Sprintf("(already added: [%s], updated: [%s], removed: [%s])",
+		strings.Join(r.Added, ", "), strings.Join(r.Updated, ", "), strings.Join(r.Removed, ", "))
+}
+
+// planHTPasswdUserChanges computes the users to add, update and remove to apply the desired
+// users to a provider that currently has the existing usernames. Results are sorted.
+func planHTPasswdUserChanges(existing []string, desired map[string]*cmv1.HTPasswdUser, mode HTPasswdOverwriteMode) (added, updated, removed []string) {
+	current := make(map[string]bool, len(existing))
+	for _, username := range existing {
+		current[username] = true
+	}
+
+	added, updated, removed = []string{}, []string{}, []string{}
+	for username := range desired {
+		if current[username] {
+			updated = append(updated, username)
+		} else {
+			added = append(added, username)
+		}
+	}
+	if mode != HTPasswdOverwriteMerge {
+		for _, username := range existing {
+			if _, ok := desired[username]; !ok {
+				removed = append(removed, username)
+			}
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(updated)
+	sort.Strings(removed)
+	return added, updated, removed
+}
+
 // SetupHTPasswdIdentityProvider - main implementation using ROSA CLI patterns
 func (c *Client) SetupHTPasswdIdentityProvider(
 	clusterID string,
@@ -42,7 +278,8 @@ func (c *Client) SetupHTPasswdIdentityProvider(
 	mappingMethod string,
 	userInput map[string]interface{}, // MCP parameter input
 	overwriteExisting bool,
-) (*cmv1.IdentityProvider, error) {
+	overwriteMode HTPasswdOverwriteMode,
+) (*HTPasswdSetupResult, error) {
 
 	// Step 1: Validate cluster exists - reusing existing MCP pattern
 	_, err := c.GetCluster(clusterID)
@@ -61,11 +298,19 @@ func (c *Client) SetupHTPasswdIdentityProvider(
 		return nil, fmt.Errorf("failed to check existing identity providers: %w", err)
 	}
 
-	if !overwriteExisting {
-		for _, idp := range existingIDPs {
-			if idp.Name() == name {
-				return nil, fmt.Errorf("identity provider with name '%s' already exists", name)
-			}
+	var existingIDP *cmv1.IdentityProvider
+	for _, idp := range existingIDPs {
+		if idp.Name() == name {
+			existingIDP = idp
+			break
+		}
+	}
+	if existingIDP != nil {
+		if !overwriteExisting {
+			return nil, fmt.Errorf("identity provider with name '%s' already exists", name)
+		}
+		if existingIDP.Type() != cmv1.IdentityProviderTypeHtpasswd {
+			return nil, fmt.Errorf("identity provider '%s' is of type %s and cannot be overwritten with an HTPasswd provider; delete it first", name, existingIDP.Type())
 		}
 	}
 
@@ -75,23 +320,20 @@ func (c *Client) SetupHTPasswdIdentityProvider(
 		return nil, fmt.Errorf("failed to process user input: %w", err)
 	}
 
-	// Step 5: Build HTPasswd user list (always hash passwords)
+	// Step 5: Build HTPasswd user list (always hash passwords). Every user is validated
+	// before anything is changed in OCM.
+	desiredUsers := make(map[string]*cmv1.HTPasswdUser, len(userList))
 	htpasswdUsers := []*cmv1.HTPasswdUserBuilder{}
 	for username, password := range userList {
-		// Validate each user using ROSA CLI validation
-		if err := htpasswd.ValidateUserCredentials(username, password); err != nil {
-			return nil, fmt.Errorf("invalid user credentials for '%s': %w", username, err)
+		userBuilder, err := buildHTPasswdUser(username, password)
+		if err != nil {
+			return nil, err
 		}
-
-		// Always hash passwords using ROSA CLI method
-		hashedPwd, err := idputils.GenerateHTPasswdCompatibleHash(password)
+		user, err := userBuilder.Build()
 		if err != nil {
-			return nil, fmt.Errorf("failed to hash password for user '%s': %w", username, err)
+			return nil, fmt.Errorf("failed to build user '%s': %w", username, err)
 		}
-		
-		userBuilder := cmv1.NewHTPasswdUser().
-			Username(username).
-			HashedPassword(hashedPwd)
+		desiredUsers[username] = user
 		htpasswdUsers = append(htpasswdUsers, userBuilder)
 	}
 
@@ -110,10 +352,151 @@ func (c *Client) SetupHTPasswdIdentityProvider(
 	}
 
 	// Step 7: Create IDP using ROSA CLI method
-	createdIdp, err := c.CreateIdentityProvider(clusterID, idp)
+	if existingIDP == nil {
+		createdIdp, err := c.CreateIdentityProvider(clusterID, idp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create identity provider: %w", err)
+		}
+		added, _, _ := planHTPasswdUserChanges(nil, desiredUsers, HTPasswdOverwriteReplaceUsers)
+		return &HTPasswdSetupResult{IdentityProvider: createdIdp, Added: added}, nil
+	}
+
+	// Step 8: Overwrite the existing IDP according to the requested mode
+	if overwriteMode == HTPasswdOverwriteRecreate {
+		return c.recreateHTPasswdIdentityProvider(clusterID, existingIDP, idp, desiredUsers)
+	}
+	if existingIDP.MappingMethod() != idp.MappingMethod() {
+		return nil, fmt.Errorf("changing the mapping method of identity provider '%s' from %s to %s requires overwrite mode '%s'",
+			name, existingIDP.MappingMethod(), idp.MappingMethod(), HTPasswdOverwriteRecreate)
+	}
+	return c.applyHTPasswdUserChanges(clusterID, existingIDP, desiredUsers, overwriteMode)
+}
+
+// applyHTPasswdUserChanges updates the users of an existing HTPasswd identity provider in place.
+// Users are added and updated before any are removed.
+func (c *Client) applyHTPasswdUserChanges(
+	clusterID string,
+	idp *cmv1.IdentityProvider,
+	desiredUsers map[string]*cmv1.HTPasswdUser,
+	mode HTPasswdOverwriteMode,
+) (*HTPasswdSetupResult, error) {
+	existingUsers, err := c.GetHTPasswdUsers(clusterID, idp.ID())
 	if err != nil {
-		return nil, fmt.Errorf("failed to create identity provider: %w", err)
+		return nil, fmt.Errorf("failed to list users of identity provider '%s': %w", idp.Name(), err)
 	}
+	existingIDs := make(map[string]string, len(existingUsers))
+	existingNames := make([]string, 0, len(existingUsers))
+	for _, user := range existingUsers {
+		existingIDs[user.Username()] = user.ID()
+		existingNames = append(existingNames, user.Username())
+	}
+
+	added, updated, removed := planHTPasswdUserChanges(existingNames, desiredUsers, mode)
+	result := &HTPasswdSetupResult{IdentityProvider: idp, Overwritten: true, Mode: mode}
+
+	glog.V(2).Infof("Overwriting users of identity provider %s on cluster %s (%s): %d added, %d updated, %d removed",
+		idp.ID(), clusterID, mode, len(added), len(updated), len(removed))
+
+	for _, username := range added {
+		if err := c.addHTPasswdUser(clusterID, idp.ID(), desiredUsers[username]); err != nil {
+			return nil, fmt.Errorf("failed to add user '%s' %s: %w", username, result.progress(), err)
+		}
+		result.Added = append(result.Added, username)
+	}
+	for _, username := range updated {
+		if err := c.updateHTPasswdUser(clusterID, idp.ID(), existingIDs[username], desiredUsers[username]); err != nil {
+			return nil, fmt.Errorf("failed to update user '%s' %s: %w", username, result.progress(), err)
+		}
+		result.Updated = append(result.Updated, username)
+	}
+	for _, username := range removed {
+		if err := c.DeleteHTPasswdUser(clusterID, idp.ID(), existingIDs[username]); err != nil {
+			return nil, fmt.Errorf("failed to remove user '%s' %s: %w", username, result.progress(), err)
+		}
+		result.Removed = append(result.Removed, username)
+	}
+
+	return result, nil
+}
 
-	return createdIdp, nil
-}
\ No newline at end of file
+// recreateHTPasswdIdentityProvider deletes an existing HTPasswd identity provider and creates the
+// replacement. If the replacement cannot be created the original provider is restored. OCM does
+// not return the password hashes of existing users, so the restored users get the passwords given
+// for them, and recreation is refused unless every existing user is given.
+func (c *Client) recreateHTPasswdIdentityProvider(
+	clusterID string,
+	existingIDP *cmv1.IdentityProvider,
+	replacement *cmv1.IdentityProvider,
+	desiredUsers map[string]*cmv1.HTPasswdUser,
+) (*HTPasswdSetupResult, error) {
+	existingUsers, err := c.GetHTPasswdUsers(clusterID, existingIDP.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users of identity provider '%s': %w", existingIDP.Name(), err)
+	}
+
+	// Snapshot the original provider so it can be restored
+	existingNames := make([]string, 0, len(existingUsers))
+	for _, user := range existingUsers {
+		existingNames = append(existingNames, user.Username())
+	}
+	restoreUsers, err := htpasswdRestoreUsers(existingNames, desiredUsers)
+	if err != nil {
+		return nil, fmt.Errorf("cannot recreate identity provider '%s': %w", existingIDP.Name(), err)
+	}
+	original, err := cmv1.NewIdentityProvider().
+		Type(cmv1.IdentityProviderTypeHtpasswd).
+		Name(existingIDP.Name()).
+		MappingMethod(existingIDP.MappingMethod()).
+		Htpasswd(cmv1.NewHTPasswdIdentityProvider().Users(cmv1.NewHTPasswdUserList().Items(restoreUsers...))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot identity provider '%s': %w", existingIDP.Name(), err)
+	}
+
+	glog.V(2).Infof("Recreating identity provider %s on cluster %s", existingIDP.ID(), clusterID)
+	if err := c.DeleteIdentityProvider(clusterID, existingIDP.ID()); err != nil {
+		return nil, fmt.Errorf("failed to delete identity provider '%s': %w", existingIDP.Name(), err)
+	}
+
+	createdIdp, err := c.CreateIdentityProvider(clusterID, replacement)
+	if err != nil {
+		glog.Errorf("Failed to recreate identity provider %s on cluster %s, restoring original: %v", existingIDP.Name(), clusterID, err)
+		if _, restoreErr := c.CreateIdentityProvider(clusterID, original); restoreErr != nil {
+			return nil, fmt.Errorf("failed to recreate identity provider '%s': %w; restoring the original provider also failed: %v",
+				existingIDP.Name(), err, restoreErr)
+		}
+		return nil, fmt.Errorf("failed to recreate identity provider '%s', the original provider was restored: %w", existingIDP.Name(), err)
+	}
+
+	added, updated, removed := planHTPasswdUserChanges(existingNames, desiredUsers, HTPasswdOverwriteRecreate)
+	glog.Infof("Successfully recreated identity provider %s on cluster %s", createdIdp.Name(), clusterID)
+	return &HTPasswdSetupResult{
+		IdentityProvider: createdIdp,
+		Overwritten:      true,
+		Mode:             HTPasswdOverwriteRecreate,
+		Added:            added,
+		Updated:          updated,
+		Removed:          removed,
+	}, nil
+}
+
+// htpasswdRestoreUsers returns the users that restore an HTPasswd identity provider with the
+// existing users, using the passwords given for them in desiredUsers
+func htpasswdRestoreUsers(existingNames []string, desiredUsers map[string]*cmv1.HTPasswdUser) ([]*cmv1.HTPasswdUserBuilder, error) {
+	var missing []string
+	restoreUsers := make([]*cmv1.HTPasswdUserBuilder, 0, len(existingNames))
+	for _, username := range existingNames {
+		user, ok := desiredUsers[username]
+		if !ok {
+			missing = append(missing, username)
+			continue
+		}
+		restoreUsers = append(restoreUsers, cmv1.NewHTPasswdUser().Username(username).HashedPassword(user.HashedPassword()))
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("the users must include every existing user, with a password, so the provider can be restored if recreation fails; "+
+			"missing: %s. Remove users with overwrite mode '%s' first", strings.Join(missing, ", "), HTPasswdOverwriteReplaceUsers)
+	}
+	return restoreUsers, nil
+}
@@ -0,0 +1,122 @@
+package ocm
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildHTPasswdUser(t *testing.T) {
+	builder, err := buildHTPasswdUser("tester", "Sup3rS3cretPassw0rd!")
+	assert.NoError(t, err)
+
+	user, err := builder.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "tester", user.Username())
+	assert.NotEmpty(t, user.HashedPassword())
+	assert.NotEqual(t, "Sup3rS3cretPassw0rd!", user.HashedPassword())
+	assert.Empty(t, user.Password())
+}
+
+func TestBuildHTPasswdUserRejectsInvalidCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		password string
+	}{
+		{name: "reserved username", username: "cluster-admin", password: "Sup3rS3cretPassw0rd!"},
+		{name: "invalid username", username: "bad/user", password: "Sup3rS3cretPassw0rd!"},
+		{name: "weak password", username: "tester", password: "short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildHTPasswdUser(tt.username, tt.password)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseHTPasswdOverwriteMode(t *testing.T) {
+	mode, err := ParseHTPasswdOverwriteMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, HTPasswdOverwriteReplaceUsers, mode)
+
+	mode, err = ParseHTPasswdOverwriteMode("merge")
+	assert.NoError(t, err)
+	assert.Equal(t, HTPasswdOverwriteMerge, mode)
+
+	_, err = ParseHTPasswdOverwriteMode("append")
+	assert.Error(t, err)
+}
+
+func TestPlanHTPasswdUserChanges(t *testing.T) {
+	existing := []string{"carol", "alice", "bob"}
+	desired := map[string]*cmv1.HTPasswdUser{
+		"alice": nil,
+		"dave":  nil,
+		"bob":   nil,
+	}
+
+	tests := []struct {
+		name            string
+		mode            HTPasswdOverwriteMode
+		expectedAdded   []string
+		expectedUpdated []string
+		expectedRemoved []string
+	}{
+		{
+			name:            "replace users removes users not in the list",
+			mode:            HTPasswdOverwriteReplaceUsers,
+			expectedAdded:   []string{"dave"},
+			expectedUpdated: []string{"alice", "bob"},
+			expectedRemoved: []string{"carol"},
+		},
+		{
+			name:            "merge keeps users not in the list",
+			mode:            HTPasswdOverwriteMerge,
+			expectedAdded:   []string{"dave"},
+			expectedUpdated: []string{"alice", "bob"},
+			expectedRemoved: []string{},
+		},
+		{
+			name:            "recreate reports the same changes as replace users",
+			mode:            HTPasswdOverwriteRecreate,
+			expectedAdded:   []string{"dave"},
+			expectedUpdated: []string{"alice", "bob"},
+			expectedRemoved: []string{"carol"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, updated, removed := planHTPasswdUserChanges(existing, desired, tt.mode)
+			assert.Equal(t, tt.expectedAdded, added)
+			assert.Equal(t, tt.expectedUpdated, updated)
+			assert.Equal(t, tt.expectedRemoved, removed)
+		})
+	}
+}
+
+func TestHTPasswdRestoreUsers(t *testing.T) {
+	desired := make(map[string]*cmv1.HTPasswdUser)
+	for _, username := range []string{"alice", "bob"} {
+		builder, err := buildHTPasswdUser(username, "Sup3rS3cretPassw0rd!")
+		assert.NoError(t, err)
+		user, err := builder.Build()
+		assert.NoError(t, err)
+		desired[username] = user
+	}
+
+	restoreUsers, err := htpasswdRestoreUsers([]string{"alice"}, desired)
+	assert.NoError(t, err)
+	assert.Len(t, restoreUsers, 1)
+	restored, err := restoreUsers[0].Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", restored.Username())
+	assert.Equal(t, desired["alice"].HashedPassword(), restored.HashedPassword())
+
+	_, err = htpasswdRestoreUsers([]string{"alice", "carol", "bob", "dave"}, desired)
+	assert.ErrorContains(t, err, "missing: carol, dave")
+}
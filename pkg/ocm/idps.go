@@ -0,0 +1,413 @@
+package ocm
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/tiwillia/rosa-mcp-go/pkg/htpasswd"
+)
+
+// FindIdentityProvider returns the identity provider of a cluster matching the given name or ID
+func (c *Client) FindIdentityProvider(clusterID, nameOrID string) (*cmv1.IdentityProvider, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	idps, err := c.GetIdentityProviders(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	for _, idp := range idps {
+		if idp.Name() == nameOrID || idp.ID() == nameOrID {
+			return idp, nil
+		}
+	}
+	return nil, fmt.Errorf("identity provider '%s' not found on cluster %s", nameOrID, clusterID)
+}
+
+// FindHTPasswdIdentityProvider returns the HTPasswd identity provider of a cluster matching the given name or ID
+func (c *Client) FindHTPasswdIdentityProvider(clusterID, nameOrID string) (*cmv1.IdentityProvider, error) {
+	idp, err := c.FindIdentityProvider(clusterID, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if idp.Type() != cmv1.IdentityProviderTypeHtpasswd {
+		return nil, fmt.Errorf("identity provider '%s' is of type %s; users can only be managed in htpasswd identity providers", nameOrID, idp.Type())
+	}
+	return idp, nil
+}
+
+// IdentityProviderConfig is implemented by the typed identity provider specs accepted by SetupIdentityProvider
+type IdentityProviderConfig interface {
+	// Validate checks the configuration before anything is sent to OCM
+	Validate() error
+	build() *cmv1.IdentityProviderBuilder
+}
+
+// IdentityProviderSpec holds the settings shared by every identity provider type
+type IdentityProviderSpec struct {
+	Name          string
+	MappingMethod string
+}
+
+// validate checks the provider name using ROSA CLI validation and the mapping method
+func (s *IdentityProviderSpec) validate() error {
+	if err := htpasswd.ValidateIdpName(s.Name); err != nil {
+		return fmt.Errorf("invalid identity provider name: %w", err)
+	}
+	switch cmv1.IdentityProviderMappingMethod(s.MappingMethod) {
+	case cmv1.IdentityProviderMappingMethodAdd, cmv1.IdentityProviderMappingMethodClaim,
+		cmv1.IdentityProviderMappingMethodGenerate, cmv1.IdentityProviderMappingMethodLookup:
+		return nil
+	default:
+		return fmt.Errorf("invalid mapping method '%s': must be one of add, claim, generate, lookup", s.MappingMethod)
+	}
+}
+
+// builder returns an identity provider builder with the shared settings applied
+func (s *IdentityProviderSpec) builder(idpType cmv1.IdentityProviderType) *cmv1.IdentityProviderBuilder {
+	return cmv1.NewIdentityProvider().
+		Type(idpType).
+		Name(s.Name).
+		MappingMethod(cmv1.IdentityProviderMappingMethod(s.MappingMethod))
+}
+
+// validateClientCredentials checks that OAuth client credentials are present
+func validateClientCredentials(clientID, clientSecret string) error {
+	if clientID == "" {
+		return fmt.Errorf("client ID is required")
+	}
+	if clientSecret == "" {
+		return fmt.Errorf("client secret is required")
+	}
+	return nil
+}
+
+// validateHTTPSURL checks that value is an absolute https URL without query or fragment
+func validateHTTPSURL(field, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s': %w", field, value, err)
+	}
+	if parsed.Scheme != "https" || parsed.Host == "" {
+		return fmt.Errorf("invalid %s '%s': must be an https URL", field, value)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("invalid %s '%s': must not contain a query or fragment", field, value)
+	}
+	return nil
+}
+
+// GithubIdentityProviderSpec describes a GitHub or GitHub Enterprise identity provider.
+// Access must be restricted to either organizations or teams.
+type GithubIdentityProviderSpec struct {
+	IdentityProviderSpec
+	ClientID      string
+	ClientSecret  string
+	Hostname      string
+	CA            string
+	Organizations []string
+	Teams         []string
+}
+
+// Validate checks the GitHub identity provider configuration
+func (s *GithubIdentityProviderSpec) Validate() error {
+	if err := s.IdentityProviderSpec.validate(); err != nil {
+		return err
+	}
+	if err := validateClientCredentials(s.ClientID, s.ClientSecret); err != nil {
+		return err
+	}
+	if s.Hostname != "" {
+		if strings.Contains(s.Hostname, "://") || strings.ContainsAny(s.Hostname, "/?#") {
+			return fmt.Errorf("invalid hostname '%s': expected a host name without scheme or path", s.Hostname)
+		}
+		if strings.EqualFold(s.Hostname, "github.com") {
+			return fmt.Errorf("hostname must only be set for GitHub Enterprise, not github.com")
+		}
+	}
+	if s.CA != "" && s.Hostname == "" {
+		return fmt.Errorf("a CA can only be set together with a GitHub Enterprise hostname")
+	}
+	if len(s.Organizations) == 0 && len(s.Teams) == 0 {
+		return fmt.Errorf("organizations or teams are required to restrict which GitHub users can log in")
+	}
+	if len(s.Organizations) > 0 && len(s.Teams) > 0 {
+		return fmt.Errorf("organizations and teams are mutually exclusive")
+	}
+	for _, team := range s.Teams {
+		org, name, found := strings.Cut(team, "/")
+		if !found || org == "" || name == "" {
+			return fmt.Errorf("invalid team '%s': expected the format org/team", team)
+		}
+	}
+	return nil
+}
+
+func (s *GithubIdentityProviderSpec) build() *cmv1.IdentityProviderBuilder {
+	github := cmv1.NewGithubIdentityProvider().
+		ClientID(s.ClientID).
+		ClientSecret(s.ClientSecret)
+	if s.Hostname != "" {
+		github = github.Hostname(s.Hostname)
+	}
+	if s.CA != "" {
+		github = github.CA(s.CA)
+	}
+	if len(s.Organizations) > 0 {
+		github = github.Organizations(s.Organizations...)
+	}
+	if len(s.Teams) > 0 {
+		github = github.Teams(s.Teams...)
+	}
+	return s.builder(cmv1.IdentityProviderTypeGithub).Github(github)
+}
+
+// GitlabIdentityProviderSpec describes a GitLab identity provider
+type GitlabIdentityProviderSpec struct {
+	IdentityProviderSpec
+	URL          string
+	ClientID     string
+	ClientSecret string
+	CA           string
+}
+
+// Validate checks the GitLab identity provider configuration
+func (s *GitlabIdentityProviderSpec) Validate() error {
+	if err := s.IdentityProviderSpec.validate(); err != nil {
+		return err
+	}
+	if err := validateClientCredentials(s.ClientID, s.ClientSecret); err != nil {
+		return err
+	}
+	return validateHTTPSURL("GitLab URL", s.URL)
+}
+
+func (s *GitlabIdentityProviderSpec) build() *cmv1.IdentityProviderBuilder {
+	gitlab := cmv1.NewGitlabIdentityProvider().
+		URL(s.URL).
+		ClientID(s.ClientID).
+		ClientSecret(s.ClientSecret)
+	if s.CA != "" {
+		gitlab = gitlab.CA(s.CA)
+	}
+	return s.builder(cmv1.IdentityProviderTypeGitlab).Gitlab(gitlab)
+}
+
+// GoogleIdentityProviderSpec describes a Google identity provider
+type GoogleIdentityProviderSpec struct {
+	IdentityProviderSpec
+	ClientID     string
+	ClientSecret string
+	HostedDomain string
+}
+
+// Validate checks the Google identity provider configuration. Without a hosted domain any
+// Google account could log in, so it is required unless users are pre-provisioned with lookup.
+func (s *GoogleIdentityProviderSpec) Validate() error {
+	if err := s.IdentityProviderSpec.validate(); err != nil {
+		return err
+	}
+	if err := validateClientCredentials(s.ClientID, s.ClientSecret); err != nil {
+		return err
+	}
+	if s.HostedDomain == "" && s.MappingMethod != string(cmv1.IdentityProviderMappingMethodLookup) {
+		return fmt.Errorf("hosted domain is required unless the mapping method is lookup")
+	}
+	if strings.ContainsAny(s.HostedDomain, "/:@ ") {
+		return fmt.Errorf("invalid hosted domain '%s': expected a domain name such as example.com", s.HostedDomain)
+	}
+	return nil
+}
+
+func (s *GoogleIdentityProviderSpec) build() *cmv1.IdentityProviderBuilder {
+	google := cmv1.NewGoogleIdentityProvider().
+		ClientID(s.ClientID).
+		ClientSecret(s.ClientSecret)
+	if s.HostedDomain != "" {
+		google = google.HostedDomain(s.HostedDomain)
+	}
+	return s.builder(cmv1.IdentityProviderTypeGoogle).Google(google)
+}
+
+// OpenIDIdentityProviderSpec describes an OpenID Connect identity provider. Empty claim lists
+// default to the standard OpenID claims.
+type OpenIDIdentityProviderSpec struct {
+	IdentityProviderSpec
+	Issuer                  string
+	ClientID                string
+	ClientSecret            string
+	CA                      string
+	ExtraScopes             []string
+	EmailClaims             []string
+	NameClaims              []string
+	PreferredUsernameClaims []string
+	GroupsClaims            []string
+}
+
+// Validate checks the OpenID identity provider configuration
+func (s *OpenIDIdentityProviderSpec) Validate() error {
+	if err := s.IdentityProviderSpec.validate(); err != nil {
+		return err
+	}
+	if err := validateClientCredentials(s.ClientID, s.ClientSecret); err != nil {
+		return err
+	}
+	return validateHTTPSURL("issuer URL", s.Issuer)
+}
+
+func (s *OpenIDIdentityProviderSpec) build() *cmv1.IdentityProviderBuilder {
+	claims := cmv1.NewOpenIDClaims().
+		Email(defaultStrings(s.EmailClaims, "email")...).
+		Name(defaultStrings(s.NameClaims, "name")...).
+		PreferredUsername(defaultStrings(s.PreferredUsernameClaims, "preferred_username")...)
+	if len(s.GroupsClaims) > 0 {
+		claims = claims.Groups(s.GroupsClaims...)
+	}
+
+	openID := cmv1.NewOpenIDIdentityProvider().
+		Issuer(s.Issuer).
+		ClientID(s.ClientID).
+		ClientSecret(s.ClientSecret).
+		Claims(claims)
+	if s.CA != "" {
+		openID = openID.CA(s.CA)
+	}
+	if len(s.ExtraScopes) > 0 {
+		openID = openID.ExtraScopes(s.ExtraScopes...)
+	}
+	return s.builder(cmv1.IdentityProviderTypeOpenID).OpenID(openID)
+}
+
+// LDAPIdentityProviderSpec describes an LDAP identity provider. Empty attribute lists default to
+// the attributes used by the ROSA CLI.
+type LDAPIdentityProviderSpec struct {
+	IdentityProviderSpec
+	URL                         string
+	BindDN                      string
+	BindPassword                string
+	CA                          string
+	Insecure                    bool
+	IDAttributes                []string
+	EmailAttributes             []string
+	NameAttributes              []string
+	PreferredUsernameAttributes []string
+}
+
+// Validate checks the LDAP identity provider configuration
+func (s *LDAPIdentityProviderSpec) Validate() error {
+	if err := s.IdentityProviderSpec.validate(); err != nil {
+		return err
+	}
+	if s.URL == "" {
+		return fmt.Errorf("LDAP URL is required")
+	}
+	parsed, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("invalid LDAP URL '%s': %w", s.URL, err)
+	}
+	if (parsed.Scheme != "ldap" && parsed.Scheme != "ldaps") || parsed.Host == "" {
+		return fmt.Errorf("invalid LDAP URL '%s': expected ldap://host[:port]/basedn?attribute?scope?filter or ldaps://...", s.URL)
+	}
+	if (s.BindDN == "") != (s.BindPassword == "") {
+		return fmt.Errorf("bind DN and bind password must be provided together")
+	}
+	if s.Insecure {
+		if parsed.Scheme == "ldaps" {
+			return fmt.Errorf("insecure connections cannot be used with an ldaps:// URL")
+		}
+		if s.CA != "" {
+			return fmt.Errorf("a CA cannot be used with insecure connections")
+		}
+	}
+	return nil
+}
+
+func (s *LDAPIdentityProviderSpec) build() *cmv1.IdentityProviderBuilder {
+	ldap := cmv1.NewLDAPIdentityProvider().
+		URL(s.URL).
+		Insecure(s.Insecure).
+		Attributes(cmv1.NewLDAPAttributes().
+			ID(defaultStrings(s.IDAttributes, "dn")...).
+			Email(defaultStrings(s.EmailAttributes, "mail")...).
+			Name(defaultStrings(s.NameAttributes, "cn")...).
+			PreferredUsername(defaultStrings(s.PreferredUsernameAttributes, "uid")...))
+	if s.BindDN != "" {
+		ldap = ldap.BindDN(s.BindDN).BindPassword(s.BindPassword)
+	}
+	if s.CA != "" {
+		ldap = ldap.CA(s.CA)
+	}
+	return s.builder(cmv1.IdentityProviderTypeLDAP).LDAP(ldap)
+}
+
+// defaultStrings returns values, or defaults when values is empty
+func defaultStrings(values []string, defaults ...string) []string {
+	if len(values) > 0 {
+		return values
+	}
+	return defaults
+}
+
+// SetupIdentityProvider validates the configuration and creates the identity provider on a cluster
+func (c *Client) SetupIdentityProvider(clusterID string, config IdentityProviderConfig) (*cmv1.IdentityProvider, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	idp, err := config.build().Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build identity provider: %w", err)
+	}
+
+	existingIDPs, err := c.GetIdentityProviders(clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing identity providers: %w", err)
+	}
+	for _, existing := range existingIDPs {
+		if existing.Name() == idp.Name() {
+			return nil, fmt.Errorf("identity provider with name '%s' already exists", idp.Name())
+		}
+	}
+
+	glog.V(2).Infof("Creating %s identity provider %s on cluster %s", idp.Type(), idp.Name(), clusterID)
+	createdIdp, err := c.CreateIdentityProvider(clusterID, idp)
+	if err != nil {
+		glog.Errorf("Failed to create identity provider %s on cluster %s: %v", idp.Name(), clusterID, err)
+		return nil, err
+	}
+
+	glog.Infof("Successfully created %s identity provider %s on cluster %s", createdIdp.Type(), createdIdp.Name(), clusterID)
+	return createdIdp, nil
+}
+
+// OAuthCallbackURL returns the OAuth callback URL that must be registered with an OAuth
+// identity provider, or an empty string if the cluster's URLs are not available yet
+func OAuthCallbackURL(cluster *cmv1.Cluster, idpName string) string {
+	var oauthURL string
+	if cluster.Hypershift().Enabled() {
+		// Hosted control planes serve OAuth next to the API server: api.<domain> -> oauth.<domain>
+		apiURL, err := url.Parse(cluster.API().URL())
+		if err != nil || !strings.HasPrefix(apiURL.Hostname(), "api.") {
+			return ""
+		}
+		oauthURL = "https://oauth." + strings.TrimPrefix(apiURL.Hostname(), "api.")
+	} else {
+		consoleURL := cluster.Console().URL()
+		if !strings.Contains(consoleURL, "console-openshift-console") {
+			return ""
+		}
+		oauthURL = strings.TrimSuffix(strings.Replace(consoleURL, "console-openshift-console", "oauth-openshift", 1), "/")
+	}
+	return oauthURL + "/oauth2callback/" + idpName
+}
@@ -0,0 +1,191 @@
+package ocm
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func validSpec(name string) IdentityProviderSpec {
+	return IdentityProviderSpec{Name: name, MappingMethod: "claim"}
+}
+
+func TestGithubIdentityProviderSpecValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      GithubIdentityProviderSpec
+		expectErr bool
+	}{
+		{
+			name:      "organizations",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: validSpec("github"), ClientID: "id", ClientSecret: "secret", Organizations: []string{"my-org"}},
+			expectErr: false,
+		},
+		{
+			name:      "teams on enterprise host",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: validSpec("github"), ClientID: "id", ClientSecret: "secret", Hostname: "github.example.com", CA: "pem", Teams: []string{"my-org/admins"}},
+			expectErr: false,
+		},
+		{
+			name:      "reserved name",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: validSpec("cluster-admin"), ClientID: "id", ClientSecret: "secret", Organizations: []string{"my-org"}},
+			expectErr: true,
+		},
+		{
+			name:      "invalid mapping method",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: IdentityProviderSpec{Name: "github", MappingMethod: "auto"}, ClientID: "id", ClientSecret: "secret", Organizations: []string{"my-org"}},
+			expectErr: true,
+		},
+		{
+			name:      "missing client secret",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: validSpec("github"), ClientID: "id", Organizations: []string{"my-org"}},
+			expectErr: true,
+		},
+		{
+			name:      "no organizations or teams",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: validSpec("github"), ClientID: "id", ClientSecret: "secret"},
+			expectErr: true,
+		},
+		{
+			name:      "organizations and teams",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: validSpec("github"), ClientID: "id", ClientSecret: "secret", Organizations: []string{"my-org"}, Teams: []string{"my-org/admins"}},
+			expectErr: true,
+		},
+		{
+			name:      "team without org",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: validSpec("github"), ClientID: "id", ClientSecret: "secret", Teams: []string{"admins"}},
+			expectErr: true,
+		},
+		{
+			name:      "hostname with scheme",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: validSpec("github"), ClientID: "id", ClientSecret: "secret", Hostname: "https://github.example.com", Organizations: []string{"my-org"}},
+			expectErr: true,
+		},
+		{
+			name:      "CA without hostname",
+			spec:      GithubIdentityProviderSpec{IdentityProviderSpec: validSpec("github"), ClientID: "id", ClientSecret: "secret", CA: "pem", Organizations: []string{"my-org"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOAuthIdentityProviderSpecsValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      IdentityProviderConfig
+		expectErr bool
+	}{
+		{
+			name:      "gitlab",
+			spec:      &GitlabIdentityProviderSpec{IdentityProviderSpec: validSpec("gitlab"), URL: "https://gitlab.com", ClientID: "id", ClientSecret: "secret"},
+			expectErr: false,
+		},
+		{
+			name:      "gitlab over http",
+			spec:      &GitlabIdentityProviderSpec{IdentityProviderSpec: validSpec("gitlab"), URL: "http://gitlab.example.com", ClientID: "id", ClientSecret: "secret"},
+			expectErr: true,
+		},
+		{
+			name:      "google with hosted domain",
+			spec:      &GoogleIdentityProviderSpec{IdentityProviderSpec: validSpec("google"), ClientID: "id", ClientSecret: "secret", HostedDomain: "example.com"},
+			expectErr: false,
+		},
+		{
+			name:      "google without hosted domain",
+			spec:      &GoogleIdentityProviderSpec{IdentityProviderSpec: validSpec("google"), ClientID: "id", ClientSecret: "secret"},
+			expectErr: true,
+		},
+		{
+			name:      "google lookup without hosted domain",
+			spec:      &GoogleIdentityProviderSpec{IdentityProviderSpec: IdentityProviderSpec{Name: "google", MappingMethod: "lookup"}, ClientID: "id", ClientSecret: "secret"},
+			expectErr: false,
+		},
+		{
+			name:      "openid",
+			spec:      &OpenIDIdentityProviderSpec{IdentityProviderSpec: validSpec("openid"), Issuer: "https://sso.example.com/realms/main", ClientID: "id", ClientSecret: "secret"},
+			expectErr: false,
+		},
+		{
+			name:      "openid issuer with query",
+			spec:      &OpenIDIdentityProviderSpec{IdentityProviderSpec: validSpec("openid"), Issuer: "https://sso.example.com?realm=main", ClientID: "id", ClientSecret: "secret"},
+			expectErr: true,
+		},
+		{
+			name:      "ldap",
+			spec:      &LDAPIdentityProviderSpec{IdentityProviderSpec: validSpec("ldap"), URL: "ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid", BindDN: "cn=reader", BindPassword: "secret"},
+			expectErr: false,
+		},
+		{
+			name:      "ldap with http URL",
+			spec:      &LDAPIdentityProviderSpec{IdentityProviderSpec: validSpec("ldap"), URL: "https://ldap.example.com"},
+			expectErr: true,
+		},
+		{
+			name:      "ldap bind DN without password",
+			spec:      &LDAPIdentityProviderSpec{IdentityProviderSpec: validSpec("ldap"), URL: "ldap://ldap.example.com", BindDN: "cn=reader"},
+			expectErr: true,
+		},
+		{
+			name:      "ldaps marked insecure",
+			spec:      &LDAPIdentityProviderSpec{IdentityProviderSpec: validSpec("ldap"), URL: "ldaps://ldap.example.com", Insecure: true},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIdentityProviderSpecBuildDefaults(t *testing.T) {
+	spec := &LDAPIdentityProviderSpec{IdentityProviderSpec: validSpec("ldap"), URL: "ldap://ldap.example.com"}
+	idp, err := spec.build().Build()
+	assert.NoError(t, err)
+	assert.Equal(t, cmv1.IdentityProviderTypeLDAP, idp.Type())
+	assert.Equal(t, []string{"dn"}, idp.LDAP().Attributes().ID())
+	assert.Equal(t, []string{"uid"}, idp.LDAP().Attributes().PreferredUsername())
+
+	openID := &OpenIDIdentityProviderSpec{IdentityProviderSpec: validSpec("openid"), Issuer: "https://sso.example.com", EmailClaims: []string{"mail"}}
+	idp, err = openID.build().Build()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mail"}, idp.OpenID().Claims().Email())
+	assert.Equal(t, []string{"preferred_username"}, idp.OpenID().Claims().PreferredUsername())
+}
+
+func TestOAuthCallbackURL(t *testing.T) {
+	hcp, err := cmv1.NewCluster().
+		Hypershift(cmv1.NewHypershift().Enabled(true)).
+		API(cmv1.NewClusterAPI().URL("https://api.my-cluster.abcd.p3.openshiftapps.com:443")).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://oauth.my-cluster.abcd.p3.openshiftapps.com/oauth2callback/github", OAuthCallbackURL(hcp, "github"))
+
+	classic, err := cmv1.NewCluster().
+		Console(cmv1.NewClusterConsole().URL("https://console-openshift-console.apps.my-cluster.abcd.p1.openshiftapps.com")).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://oauth-openshift.apps.my-cluster.abcd.p1.openshiftapps.com/oauth2callback/google", OAuthCallbackURL(classic, "google"))
+
+	pending, err := cmv1.NewCluster().Hypershift(cmv1.NewHypershift().Enabled(true)).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "", OAuthCallbackURL(pending, "github"))
+}
@@ -0,0 +1,56 @@
+package ocm
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// LogType identifies which cluster log to retrieve
+type LogType string
+
+const (
+	// InstallLog is the cluster installation log
+	InstallLog LogType = "install"
+
+	// UninstallLog is the cluster uninstallation log
+	UninstallLog LogType = "uninstall"
+)
+
+// GetClusterLogs returns the content of a cluster's install or uninstall log.
+// offset skips that many lines from the start of the log; tail returns only the last
+// tail lines. OCM accepts only one of the two, so tail takes precedence when both are set.
+func (c *Client) GetClusterLogs(clusterID string, logType LogType, offset, tail int) (string, error) {
+	if c.connection == nil {
+		return "", fmt.Errorf("client not authenticated")
+	}
+
+	logs := c.connection.ClustersMgmt().V1().Clusters().Cluster(clusterID).Logs()
+
+	var logClient *cmv1.LogClient
+	switch logType {
+	case InstallLog:
+		logClient = logs.Install()
+	case UninstallLog:
+		logClient = logs.Uninstall()
+	default:
+		return "", fmt.Errorf("unsupported log type: %s", logType)
+	}
+
+	request := logClient.Get()
+	if tail > 0 {
+		request = request.Tail(tail)
+	} else if offset > 0 {
+		request = request.Offset(offset)
+	}
+
+	glog.V(2).Infof("Retrieving %s logs for cluster %s (offset: %d, tail: %d)", logType, clusterID, offset, tail)
+	response, err := request.Send()
+	if err != nil {
+		glog.Errorf("Failed to get %s logs for cluster %s: %v", logType, clusterID, err)
+		return "", HandleOCMError(err)
+	}
+
+	return response.Body().Content(), nil
+}
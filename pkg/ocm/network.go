@@ -0,0 +1,223 @@
+package ocm
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	// DefaultMachineCIDR is the machine network OCM assigns when none is given
+	DefaultMachineCIDR = "10.0.0.0/16"
+	// DefaultServiceCIDR is the service network OCM assigns when none is given
+	DefaultServiceCIDR = "172.30.0.0/16"
+	// DefaultPodCIDR is the pod network OCM assigns when none is given
+	DefaultPodCIDR = "10.128.0.0/14"
+	// DefaultHostPrefix is the per-node pod subnet size OCM assigns when none is given
+	DefaultHostPrefix = 23
+
+	// NetworkTypeOVNKubernetes is the default network plugin of ROSA HCP clusters
+	NetworkTypeOVNKubernetes = "OVNKubernetes"
+	// NetworkTypeOther creates the cluster without a network plugin so that a custom CNI can be installed
+	NetworkTypeOther = "Other"
+
+	minHostPrefix = 23
+	maxHostPrefix = 26
+	// maxServiceCIDRPrefix is the smallest service network accepted, /24
+	maxServiceCIDRPrefix = 24
+	// maxSingleAZMachineCIDRPrefix and maxMultiAZMachineCIDRPrefix leave room for a subnet per availability zone
+	maxSingleAZMachineCIDRPrefix = 25
+	maxMultiAZMachineCIDRPrefix  = 24
+)
+
+// validNetworkTypes lists the network types accepted for ROSA HCP clusters
+var validNetworkTypes = []string{NetworkTypeOVNKubernetes, NetworkTypeOther}
+
+// ovnReservedCIDRs are used internally by OVN-Kubernetes and must not overlap the cluster networks
+var ovnReservedCIDRs = []string{"100.64.0.0/16", "100.88.0.0/16"}
+
+// ClusterNetwork describes the networks of a new cluster. Empty fields fall back to the OCM defaults.
+type ClusterNetwork struct {
+	Type        string
+	MachineCIDR string
+	ServiceCIDR string
+	PodCIDR     string
+	HostPrefix  *int
+}
+
+// effective returns the network with the OCM defaults filled in for the unset fields
+func (n *ClusterNetwork) effective() ClusterNetwork {
+	result := ClusterNetwork{
+		Type:        NetworkTypeOVNKubernetes,
+		MachineCIDR: DefaultMachineCIDR,
+		ServiceCIDR: DefaultServiceCIDR,
+		PodCIDR:     DefaultPodCIDR,
+		HostPrefix:  intPtrValue(DefaultHostPrefix),
+	}
+	if n == nil {
+		return result
+	}
+	if n.Type != "" {
+		result.Type = n.Type
+	}
+	if n.MachineCIDR != "" {
+		result.MachineCIDR = n.MachineCIDR
+	}
+	if n.ServiceCIDR != "" {
+		result.ServiceCIDR = n.ServiceCIDR
+	}
+	if n.PodCIDR != "" {
+		result.PodCIDR = n.PodCIDR
+	}
+	if n.HostPrefix != nil {
+		result.HostPrefix = n.HostPrefix
+	}
+	return result
+}
+
+// Validate checks the network settings of a cluster with the given availability zone layout and
+// maximum number of compute nodes
+func (n *ClusterNetwork) Validate(multiAZ bool, maxNodes int) error {
+	if n != nil && n.Type != "" && !slices.Contains(validNetworkTypes, n.Type) {
+		return fmt.Errorf("invalid network type '%s': must be one of %s", n.Type, strings.Join(validNetworkTypes, ", "))
+	}
+
+	network := n.effective()
+	machine, err := parseIPv4CIDR("machine_cidr", network.MachineCIDR)
+	if err != nil {
+		return err
+	}
+	service, err := parseIPv4CIDR("service_cidr", network.ServiceCIDR)
+	if err != nil {
+		return err
+	}
+	pod, err := parseIPv4CIDR("pod_cidr", network.PodCIDR)
+	if err != nil {
+		return err
+	}
+
+	hostPrefix := *network.HostPrefix
+	if hostPrefix < minHostPrefix || hostPrefix > maxHostPrefix {
+		return fmt.Errorf("invalid host_prefix %d: must be between %d and %d", hostPrefix, minHostPrefix, maxHostPrefix)
+	}
+
+	machineBits, _ := machine.Mask.Size()
+	maxMachineBits := maxSingleAZMachineCIDRPrefix
+	if multiAZ {
+		maxMachineBits = maxMultiAZMachineCIDRPrefix
+	}
+	if machineBits > maxMachineBits {
+		return fmt.Errorf("machine_cidr %s is too small: the prefix length must be /%d or less", machine, maxMachineBits)
+	}
+
+	serviceBits, _ := service.Mask.Size()
+	if serviceBits > maxServiceCIDRPrefix {
+		return fmt.Errorf("service_cidr %s is too small: the prefix length must be /%d or less", service, maxServiceCIDRPrefix)
+	}
+
+	podBits, _ := pod.Mask.Size()
+	if podBits >= hostPrefix {
+		return fmt.Errorf("pod_cidr %s must be larger than the /%d host prefix", pod, hostPrefix)
+	}
+	if maxNodes > 0 && hostPrefix-podBits < 31 && 1<<(hostPrefix-podBits) < maxNodes {
+		return fmt.Errorf("pod_cidr %s with host prefix /%d only has room for %d nodes, but the cluster can scale to %d",
+			pod, hostPrefix, 1<<(hostPrefix-podBits), maxNodes)
+	}
+
+	named := []struct {
+		name string
+		cidr *net.IPNet
+	}{
+		{"machine_cidr", machine},
+		{"service_cidr", service},
+		{"pod_cidr", pod},
+	}
+	for i := range named {
+		for j := i + 1; j < len(named); j++ {
+			if cidrsOverlap(named[i].cidr, named[j].cidr) {
+				return fmt.Errorf("%s %s overlaps %s %s", named[i].name, named[i].cidr, named[j].name, named[j].cidr)
+			}
+		}
+	}
+
+	if network.Type == NetworkTypeOVNKubernetes {
+		for _, reserved := range ovnReservedCIDRs {
+			_, reservedNet, _ := net.ParseCIDR(reserved)
+			for _, cidr := range named {
+				if cidrsOverlap(cidr.cidr, reservedNet) {
+					return fmt.Errorf("%s %s overlaps %s, which is reserved by OVN-Kubernetes", cidr.name, cidr.cidr, reserved)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateSubnetCIDRs checks that the machine network contains every subnet whose CIDR is known
+func (n *ClusterNetwork) ValidateSubnetCIDRs(subnets []*cmv1.Subnetwork) error {
+	network := n.effective()
+	machine, err := parseIPv4CIDR("machine_cidr", network.MachineCIDR)
+	if err != nil {
+		return err
+	}
+	for _, subnet := range subnets {
+		if subnet.CIDRBlock() == "" {
+			continue
+		}
+		_, subnetNet, err := net.ParseCIDR(subnet.CIDRBlock())
+		if err != nil {
+			continue
+		}
+		if !cidrContains(machine, subnetNet) {
+			return fmt.Errorf("subnet '%s' (%s) is outside machine_cidr %s; set machine_cidr to a range that contains the VPC subnets",
+				subnet.SubnetID(), subnet.CIDRBlock(), machine)
+		}
+	}
+	return nil
+}
+
+// build returns the OCM network payload, or nil when every setting uses the OCM default
+func (n *ClusterNetwork) build() *cmv1.NetworkBuilder {
+	if n == nil || (n.Type == "" && n.MachineCIDR == "" && n.ServiceCIDR == "" && n.PodCIDR == "" && n.HostPrefix == nil) {
+		return nil
+	}
+	network := n.effective()
+	return cmv1.NewNetwork().
+		Type(network.Type).
+		MachineCIDR(network.MachineCIDR).
+		ServiceCIDR(network.ServiceCIDR).
+		PodCIDR(network.PodCIDR).
+		HostPrefix(*network.HostPrefix)
+}
+
+// parseIPv4CIDR parses an IPv4 CIDR that must be given as its network address, e.g. 10.0.0.0/16
+func parseIPv4CIDR(field, value string) (*net.IPNet, error) {
+	ip, ipNet, err := net.ParseCIDR(value)
+	if err != nil || ip.To4() == nil {
+		return nil, fmt.Errorf("invalid %s '%s': expected an IPv4 CIDR such as 10.0.0.0/16", field, value)
+	}
+	if !ip.Equal(ipNet.IP) {
+		return nil, fmt.Errorf("invalid %s '%s': host bits are set, did you mean %s?", field, value, ipNet)
+	}
+	return ipNet, nil
+}
+
+// cidrsOverlap reports whether two networks share any address
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// cidrContains reports whether inner lies entirely within outer
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerBits, _ := outer.Mask.Size()
+	innerBits, _ := inner.Mask.Size()
+	return outer.Contains(inner.IP) && innerBits >= outerBits
+}
+
+// intPtrValue returns a pointer to v
+func intPtrValue(v int) *int {
+	return &v
+}
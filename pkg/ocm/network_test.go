@@ -0,0 +1,99 @@
+package ocm
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterNetworkValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		network  *ClusterNetwork
+		multiAZ  bool
+		maxNodes int
+		wantErr  bool
+	}{
+		{name: "defaults", network: nil, multiAZ: true, maxNodes: 2},
+		{name: "custom networks", network: &ClusterNetwork{
+			MachineCIDR: "10.20.0.0/16",
+			ServiceCIDR: "172.31.0.0/16",
+			PodCIDR:     "10.132.0.0/14",
+			HostPrefix:  intPtr(24),
+		}, multiAZ: true, maxNodes: 100},
+		{name: "custom cni", network: &ClusterNetwork{Type: NetworkTypeOther}, multiAZ: true, maxNodes: 2},
+		{name: "single az /25 machine cidr", network: &ClusterNetwork{MachineCIDR: "10.0.0.0/25"}, multiAZ: false, maxNodes: 2},
+		{name: "multi az /25 machine cidr", network: &ClusterNetwork{MachineCIDR: "10.0.0.0/25"}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "unknown network type", network: &ClusterNetwork{Type: "OpenShiftSDN"}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "not a cidr", network: &ClusterNetwork{MachineCIDR: "10.0.0.0"}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "ipv6", network: &ClusterNetwork{ServiceCIDR: "fd02::/112"}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "host bits set", network: &ClusterNetwork{MachineCIDR: "10.0.1.0/16"}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "host prefix too small", network: &ClusterNetwork{HostPrefix: intPtr(22)}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "host prefix too large", network: &ClusterNetwork{HostPrefix: intPtr(27)}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "service cidr too small", network: &ClusterNetwork{ServiceCIDR: "172.30.0.0/25"}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "pod cidr smaller than host prefix", network: &ClusterNetwork{PodCIDR: "10.128.0.0/24", HostPrefix: intPtr(23)}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "pod cidr too small for nodes", network: &ClusterNetwork{PodCIDR: "10.128.0.0/20"}, multiAZ: true, maxNodes: 10, wantErr: true},
+		{name: "machine and pod overlap", network: &ClusterNetwork{MachineCIDR: "10.128.0.0/16"}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "service inside machine", network: &ClusterNetwork{ServiceCIDR: "10.0.10.0/24"}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "ovn reserved range", network: &ClusterNetwork{ServiceCIDR: "100.64.0.0/16"}, multiAZ: true, maxNodes: 2, wantErr: true},
+		{name: "ovn reserved range with custom cni", network: &ClusterNetwork{Type: NetworkTypeOther, ServiceCIDR: "100.64.0.0/16"}, multiAZ: true, maxNodes: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.network.Validate(tt.multiAZ, tt.maxNodes)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClusterNetworkValidateSubnetCIDRs(t *testing.T) {
+	subnets := []*cmv1.Subnetwork{
+		testSubnet(t, "subnet-0123456789abcdef0", false, "10.0.0.0/24"),
+		testSubnet(t, "subnet-0123456789abcdef1", true, "10.0.1.0/24"),
+		testSubnet(t, "subnet-0123456789abcdef2", true, ""),
+	}
+
+	var defaults *ClusterNetwork
+	assert.NoError(t, defaults.ValidateSubnetCIDRs(subnets))
+	assert.NoError(t, (&ClusterNetwork{MachineCIDR: "10.0.0.0/23"}).ValidateSubnetCIDRs(subnets))
+
+	err := (&ClusterNetwork{MachineCIDR: "10.0.0.0/24"}).ValidateSubnetCIDRs(subnets)
+	assert.ErrorContains(t, err, "subnet-0123456789abcdef1")
+
+	err = (&ClusterNetwork{MachineCIDR: "192.168.0.0/16"}).ValidateSubnetCIDRs(subnets)
+	assert.ErrorContains(t, err, "subnet-0123456789abcdef0")
+}
+
+func TestClusterCreateSpecNetwork(t *testing.T) {
+	cluster, err := testClusterCreateSpec().build()
+	assert.NoError(t, err)
+	assert.Nil(t, cluster.Network())
+
+	spec := testClusterCreateSpec()
+	spec.Network = &ClusterNetwork{MachineCIDR: "10.20.0.0/16", HostPrefix: intPtr(24)}
+	assert.NoError(t, spec.Validate())
+
+	cluster, err = spec.build()
+	assert.NoError(t, err)
+	assert.Equal(t, NetworkTypeOVNKubernetes, cluster.Network().Type())
+	assert.Equal(t, "10.20.0.0/16", cluster.Network().MachineCIDR())
+	assert.Equal(t, DefaultServiceCIDR, cluster.Network().ServiceCIDR())
+	assert.Equal(t, DefaultPodCIDR, cluster.Network().PodCIDR())
+	assert.Equal(t, 24, cluster.Network().HostPrefix())
+
+	// The subnets of the test spec are outside the custom machine network
+	subnets := []*cmv1.Subnetwork{
+		testSubnet(t, "subnet-0123456789abcdef0", false, "10.0.0.0/24"),
+		testSubnet(t, "subnet-0123456789abcdef1", true, "10.0.1.0/24"),
+	}
+	assert.ErrorContains(t, spec.ValidateSubnets(subnets), "machine_cidr")
+
+	spec.Scaling = &NodePoolScaling{MinReplicas: intPtr(2), MaxReplicas: intPtr(600)}
+	spec.Network.PodCIDR = "10.128.0.0/16"
+	assert.Error(t, spec.Validate())
+}
@@ -0,0 +1,93 @@
+package ocm
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Subnet network verification states reported by OCM
+const (
+	NetworkVerificationPending = "pending"
+	NetworkVerificationRunning = "running"
+	NetworkVerificationPassed  = "passed"
+	NetworkVerificationFailed  = "failed"
+)
+
+// StartNetworkVerification starts an OCM network verification of the given subnets, which checks
+// that each subnet reaches the egress endpoints a ROSA HCP cluster needs. OCM runs the checks in
+// the customer account through the installer role, and tracks one verification per subnet ID.
+func (c *Client) StartNetworkVerification(installerRoleARN, region string, subnetIDs []string) ([]*cmv1.SubnetNetworkVerification, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	body, err := cmv1.NewNetworkVerification().
+		CloudProviderData(cmv1.NewCloudProviderData().
+			AWS(cmv1.NewAWS().STS(cmv1.NewSTS().RoleARN(installerRoleARN))).
+			Region(cmv1.NewCloudRegion().ID(region)).
+			Subnets(subnetIDs...)).
+		Platform(cmv1.PlatformAwsHostedCp).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build network verification: %w", err)
+	}
+
+	glog.V(2).Infof("Starting network verification of %d subnets in region %s", len(subnetIDs), region)
+	response, err := c.connection.ClustersMgmt().V1().NetworkVerifications().Add().Body(body).Send()
+	if err != nil {
+		glog.Errorf("Failed to start network verification in region %s: %v", region, err)
+		return nil, HandleOCMError(err)
+	}
+
+	glog.Infof("Successfully started network verification of subnets: %v", subnetIDs)
+	return response.Body().Items(), nil
+}
+
+// GetNetworkVerifications returns the latest network verification of each subnet
+func (c *Client) GetNetworkVerifications(subnetIDs []string) ([]*cmv1.SubnetNetworkVerification, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	verifications := make([]*cmv1.SubnetNetworkVerification, 0, len(subnetIDs))
+	for _, subnetID := range subnetIDs {
+		glog.V(2).Infof("Retrieving network verification of subnet: %s", subnetID)
+		response, err := c.connection.ClustersMgmt().V1().NetworkVerifications().NetworkVerification(subnetID).Get().Send()
+		if err != nil {
+			glog.Errorf("Failed to get network verification of subnet %s: %v", subnetID, err)
+			return nil, HandleOCMError(err)
+		}
+		verifications = append(verifications, response.Body())
+	}
+	return verifications, nil
+}
+
+// NetworkVerificationDone reports whether every subnet verification has finished
+func NetworkVerificationDone(verifications []*cmv1.SubnetNetworkVerification) bool {
+	for _, verification := range verifications {
+		if verification.State() != NetworkVerificationPassed && verification.State() != NetworkVerificationFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateNetworkVerification returns an error unless every given subnet has a passed verification
+func ValidateNetworkVerification(verifications []*cmv1.SubnetNetworkVerification, subnetIDs []string) error {
+	states := make(map[string]string, len(verifications))
+	for _, verification := range verifications {
+		states[verification.ID()] = verification.State()
+	}
+	for _, subnetID := range subnetIDs {
+		state, ok := states[subnetID]
+		switch {
+		case !ok:
+			return fmt.Errorf("subnet '%s' was not verified", subnetID)
+		case state != NetworkVerificationPassed:
+			return fmt.Errorf("network verification of subnet '%s' is '%s'", subnetID, state)
+		}
+	}
+	return nil
+}
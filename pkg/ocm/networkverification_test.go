@@ -0,0 +1,33 @@
+package ocm
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testNetworkVerifications builds subnet network verifications from subnet ID and state pairs
+func testNetworkVerifications(t *testing.T, states ...string) []*cmv1.SubnetNetworkVerification {
+	var verifications []*cmv1.SubnetNetworkVerification
+	for i := 0; i < len(states); i += 2 {
+		verification, err := cmv1.NewSubnetNetworkVerification().ID(states[i]).State(states[i+1]).Build()
+		assert.NoError(t, err)
+		verifications = append(verifications, verification)
+	}
+	return verifications
+}
+
+func TestNetworkVerificationDone(t *testing.T) {
+	assert.True(t, NetworkVerificationDone(testNetworkVerifications(t, "subnet-1", "passed", "subnet-2", "failed")))
+	assert.False(t, NetworkVerificationDone(testNetworkVerifications(t, "subnet-1", "passed", "subnet-2", "running")))
+	assert.False(t, NetworkVerificationDone(testNetworkVerifications(t, "subnet-1", "pending")))
+}
+
+func TestValidateNetworkVerification(t *testing.T) {
+	verifications := testNetworkVerifications(t, "subnet-1", "passed", "subnet-2", "passed", "subnet-3", "failed")
+
+	assert.NoError(t, ValidateNetworkVerification(verifications, []string{"subnet-1", "subnet-2"}))
+	assert.ErrorContains(t, ValidateNetworkVerification(verifications, []string{"subnet-1", "subnet-3"}), "subnet 'subnet-3' is 'failed'")
+	assert.ErrorContains(t, ValidateNetworkVerification(verifications, []string{"subnet-4"}), "was not verified")
+}
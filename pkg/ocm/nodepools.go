@@ -0,0 +1,301 @@
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// nodePoolNameRE matches valid HCP node pool names - same rule as rosa/cmd/create/machinepool
+var nodePoolNameRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// maxNodePoolNameLength is the maximum length of an HCP node pool name
+const maxNodePoolNameLength = 15
+
+// validTaintEffects lists the taint effects accepted by OCM
+var validTaintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// NodePoolTaint describes a Kubernetes taint applied to the nodes of a node pool
+type NodePoolTaint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+// NodePoolScaling describes either a fixed replica count or autoscaling bounds.
+// Exactly one of Replicas or MinReplicas/MaxReplicas must be set.
+type NodePoolScaling struct {
+	Replicas    *int
+	MinReplicas *int
+	MaxReplicas *int
+}
+
+// NodePoolSpec describes a node pool to create or the fields of a node pool to update.
+// Nil or empty fields are left unset in the request payload.
+type NodePoolSpec struct {
+	Name         string
+	InstanceType string
+	SubnetID     string
+	Version      string
+	Scaling      *NodePoolScaling
+	Labels       map[string]string
+	Taints       []NodePoolTaint
+	AutoRepair   *bool
+}
+
+// ValidateNodePoolName validates an HCP node pool name
+func ValidateNodePoolName(name string) error {
+	if name == "" {
+		return fmt.Errorf("node pool name is required")
+	}
+	if len(name) > maxNodePoolNameLength {
+		return fmt.Errorf("node pool name '%s' must be at most %d characters", name, maxNodePoolNameLength)
+	}
+	if !nodePoolNameRE.MatchString(name) {
+		return fmt.Errorf("node pool name '%s' must consist of lower-case alphanumeric characters or '-', "+
+			"start with a letter and end with an alphanumeric character", name)
+	}
+	return nil
+}
+
+// Validate checks that exactly one scaling mode is set with sensible values
+func (s *NodePoolScaling) Validate() error {
+	autoscaling := s.MinReplicas != nil || s.MaxReplicas != nil
+	if s.Replicas != nil && autoscaling {
+		return fmt.Errorf("replicas cannot be combined with min_replicas/max_replicas")
+	}
+	if s.Replicas == nil && !autoscaling {
+		return fmt.Errorf("either replicas or min_replicas and max_replicas must be provided")
+	}
+	if s.Replicas != nil {
+		if *s.Replicas < 0 {
+			return fmt.Errorf("replicas must be a non-negative number, got %d", *s.Replicas)
+		}
+		return nil
+	}
+	if s.MinReplicas == nil || s.MaxReplicas == nil {
+		return fmt.Errorf("autoscaling requires both min_replicas and max_replicas")
+	}
+	if *s.MinReplicas < 1 {
+		return fmt.Errorf("min_replicas must be at least 1 when autoscaling, got %d", *s.MinReplicas)
+	}
+	if *s.MaxReplicas < *s.MinReplicas {
+		return fmt.Errorf("max_replicas (%d) must be greater than or equal to min_replicas (%d)",
+			*s.MaxReplicas, *s.MinReplicas)
+	}
+	return nil
+}
+
+// ParseNodePoolTaints parses taints in the rosa CLI format key=value:Effect
+func ParseNodePoolTaints(values []string) ([]NodePoolTaint, error) {
+	taints := make([]NodePoolTaint, 0, len(values))
+	for _, value := range values {
+		keyValue, effect, found := strings.Cut(value, ":")
+		if !found || effect == "" {
+			return nil, fmt.Errorf("invalid taint '%s': expected format key=value:Effect", value)
+		}
+		key, val, _ := strings.Cut(keyValue, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid taint '%s': key is required", value)
+		}
+
+		validEffect := false
+		for _, e := range validTaintEffects {
+			if effect == e {
+				validEffect = true
+				break
+			}
+		}
+		if !validEffect {
+			return nil, fmt.Errorf("invalid taint effect '%s' in '%s': must be one of %s",
+				effect, value, strings.Join(validTaintEffects, ", "))
+		}
+
+		taints = append(taints, NodePoolTaint{Key: key, Value: val, Effect: effect})
+	}
+	return taints, nil
+}
+
+// buildNodePool converts a NodePoolSpec into an OCM node pool builder
+func buildNodePool(spec *NodePoolSpec) *cmv1.NodePoolBuilder {
+	builder := cmv1.NewNodePool()
+
+	if spec.Name != "" {
+		builder = builder.ID(spec.Name)
+	}
+	if spec.InstanceType != "" {
+		builder = builder.AWSNodePool(cmv1.NewAWSNodePool().InstanceType(spec.InstanceType))
+	}
+	if spec.SubnetID != "" {
+		builder = builder.Subnet(spec.SubnetID)
+	}
+	if spec.Version != "" {
+		builder = builder.Version(cmv1.NewVersion().ID(spec.Version))
+	}
+	if spec.Scaling != nil {
+		if spec.Scaling.Replicas != nil {
+			builder = builder.Replicas(*spec.Scaling.Replicas)
+		} else {
+			builder = builder.Autoscaling(cmv1.NewNodePoolAutoscaling().
+				MinReplica(*spec.Scaling.MinReplicas).
+				MaxReplica(*spec.Scaling.MaxReplicas))
+		}
+	}
+	if spec.Labels != nil {
+		builder = builder.Labels(spec.Labels)
+	}
+	if spec.Taints != nil {
+		taintBuilders := make([]*cmv1.TaintBuilder, 0, len(spec.Taints))
+		for _, taint := range spec.Taints {
+			taintBuilders = append(taintBuilders, cmv1.NewTaint().
+				Key(taint.Key).
+				Value(taint.Value).
+				Effect(taint.Effect))
+		}
+		builder = builder.Taints(taintBuilders...)
+	}
+	if spec.AutoRepair != nil {
+		builder = builder.AutoRepair(*spec.AutoRepair)
+	}
+
+	return builder
+}
+
+// GetNodePools returns the node pools of a cluster
+func (c *Client) GetNodePools(clusterID string) ([]*cmv1.NodePool, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Retrieving node pools for cluster: %s", clusterID)
+	response, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		NodePools().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to get node pools for cluster %s: %v", clusterID, err)
+		return nil, HandleOCMError(err)
+	}
+
+	nodePools := response.Items().Slice()
+	glog.V(2).Infof("Retrieved %d node pools for cluster %s", len(nodePools), clusterID)
+	return nodePools, nil
+}
+
+// GetNodePool returns a single node pool of a cluster
+func (c *Client) GetNodePool(clusterID, nodePoolID string) (*cmv1.NodePool, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Retrieving node pool %s for cluster: %s", nodePoolID, clusterID)
+	response, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		NodePools().NodePool(nodePoolID).
+		Get().
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to get node pool %s for cluster %s: %v", nodePoolID, clusterID, err)
+		return nil, HandleOCMError(err)
+	}
+	return response.Body(), nil
+}
+
+// CreateNodePool creates a new node pool on a cluster
+func (c *Client) CreateNodePool(clusterID string, spec *NodePoolSpec) (*cmv1.NodePool, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	if err := ValidateNodePoolName(spec.Name); err != nil {
+		return nil, err
+	}
+	if spec.InstanceType == "" {
+		return nil, fmt.Errorf("instance type is required")
+	}
+	if spec.SubnetID == "" {
+		return nil, fmt.Errorf("subnet ID is required")
+	}
+	if spec.Scaling == nil {
+		return nil, fmt.Errorf("either replicas or min_replicas and max_replicas must be provided")
+	}
+	if err := spec.Scaling.Validate(); err != nil {
+		return nil, err
+	}
+
+	glog.V(2).Infof("Creating node pool %s on cluster %s", spec.Name, clusterID)
+	nodePool, err := buildNodePool(spec).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build node pool payload: %w", err)
+	}
+
+	response, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		NodePools().
+		Add().Body(nodePool).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to create node pool %s on cluster %s: %v", spec.Name, clusterID, err)
+		return nil, HandleOCMError(err)
+	}
+
+	glog.Infof("Successfully created node pool %s on cluster %s", spec.Name, clusterID)
+	return response.Body(), nil
+}
+
+// UpdateNodePool patches an existing node pool with the fields set in spec
+func (c *Client) UpdateNodePool(clusterID, nodePoolID string, spec *NodePoolSpec) (*cmv1.NodePool, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	if spec.Scaling != nil {
+		if err := spec.Scaling.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	glog.V(2).Infof("Updating node pool %s on cluster %s", nodePoolID, clusterID)
+	patch, err := buildNodePool(spec).ID(nodePoolID).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build node pool payload: %w", err)
+	}
+
+	response, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		NodePools().NodePool(nodePoolID).
+		Update().Body(patch).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to update node pool %s on cluster %s: %v", nodePoolID, clusterID, err)
+		return nil, HandleOCMError(err)
+	}
+
+	glog.Infof("Successfully updated node pool %s on cluster %s", nodePoolID, clusterID)
+	return response.Body(), nil
+}
+
+// DeleteNodePool deletes a node pool from a cluster
+func (c *Client) DeleteNodePool(clusterID, nodePoolID string) error {
+	if c.connection == nil {
+		return fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Deleting node pool %s from cluster %s", nodePoolID, clusterID)
+	_, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		NodePools().NodePool(nodePoolID).
+		Delete().
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to delete node pool %s from cluster %s: %v", nodePoolID, clusterID, err)
+		return HandleOCMError(err)
+	}
+
+	glog.Infof("Successfully initiated deletion of node pool %s from cluster %s", nodePoolID, clusterID)
+	return nil
+}
@@ -0,0 +1,86 @@
+package ocm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestValidateNodePoolName(t *testing.T) {
+	tests := []struct {
+		name     string
+		poolName string
+		wantErr  bool
+	}{
+		{name: "valid name", poolName: "workers", wantErr: false},
+		{name: "valid name with dash", poolName: "gpu-pool-1", wantErr: false},
+		{name: "empty name", poolName: "", wantErr: true},
+		{name: "too long", poolName: "a-very-long-pool-name", wantErr: true},
+		{name: "upper case", poolName: "Workers", wantErr: true},
+		{name: "starts with digit", poolName: "1pool", wantErr: true},
+		{name: "ends with dash", poolName: "pool-", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNodePoolName(tt.poolName)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodePoolScalingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		scaling NodePoolScaling
+		wantErr bool
+	}{
+		{name: "fixed replicas", scaling: NodePoolScaling{Replicas: intPtr(2)}, wantErr: false},
+		{name: "zero replicas", scaling: NodePoolScaling{Replicas: intPtr(0)}, wantErr: false},
+		{name: "negative replicas", scaling: NodePoolScaling{Replicas: intPtr(-1)}, wantErr: true},
+		{name: "autoscaling", scaling: NodePoolScaling{MinReplicas: intPtr(2), MaxReplicas: intPtr(5)}, wantErr: false},
+		{name: "autoscaling missing max", scaling: NodePoolScaling{MinReplicas: intPtr(2)}, wantErr: true},
+		{name: "autoscaling max below min", scaling: NodePoolScaling{MinReplicas: intPtr(3), MaxReplicas: intPtr(2)}, wantErr: true},
+		{name: "autoscaling min zero", scaling: NodePoolScaling{MinReplicas: intPtr(0), MaxReplicas: intPtr(2)}, wantErr: true},
+		{name: "both modes", scaling: NodePoolScaling{Replicas: intPtr(2), MinReplicas: intPtr(1), MaxReplicas: intPtr(3)}, wantErr: true},
+		{name: "no mode", scaling: NodePoolScaling{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.scaling.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseNodePoolTaints(t *testing.T) {
+	taints, err := ParseNodePoolTaints([]string{"dedicated=gpu:NoSchedule", "spot:PreferNoSchedule"})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodePoolTaint{
+		{Key: "dedicated", Value: "gpu", Effect: "NoSchedule"},
+		{Key: "spot", Value: "", Effect: "PreferNoSchedule"},
+	}, taints)
+
+	invalid := []string{
+		"dedicated=gpu",
+		"=gpu:NoSchedule",
+		"dedicated=gpu:Sometimes",
+	}
+	for _, value := range invalid {
+		_, err := ParseNodePoolTaints([]string{value})
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,126 @@
+package ocm
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// ListOIDCConfigs returns the OIDC configurations of the current organization, newest first
+func (c *Client) ListOIDCConfigs() ([]*cmv1.OidcConfig, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Retrieving OIDC configurations")
+	response, err := c.connection.ClustersMgmt().V1().OidcConfigs().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list OIDC configurations: %v", err)
+		return nil, HandleOCMError(err)
+	}
+
+	configs := response.Items().Slice()
+	sort.SliceStable(configs, func(i, j int) bool {
+		return configs[i].CreationTimestamp().After(configs[j].CreationTimestamp())
+	})
+	glog.V(2).Infof("Retrieved %d OIDC configurations", len(configs))
+	return configs, nil
+}
+
+// GetOIDCConfig returns a single OIDC configuration by ID
+func (c *Client) GetOIDCConfig(oidcConfigID string) (*cmv1.OidcConfig, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Retrieving OIDC configuration: %s", oidcConfigID)
+	response, err := c.connection.ClustersMgmt().V1().OidcConfigs().OidcConfig(oidcConfigID).Get().Send()
+	if err != nil {
+		glog.Errorf("Failed to get OIDC configuration %s: %v", oidcConfigID, err)
+		return nil, HandleOCMError(err)
+	}
+	return response.Body(), nil
+}
+
+// CreateManagedOIDCConfig creates a reusable OIDC configuration whose issuer and signing keys are
+// hosted by Red Hat. The OIDC provider still has to be registered in the AWS account.
+func (c *Client) CreateManagedOIDCConfig() (*cmv1.OidcConfig, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	config, err := cmv1.NewOidcConfig().Managed(true).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OIDC configuration: %w", err)
+	}
+
+	glog.V(2).Infof("Creating managed OIDC configuration")
+	response, err := c.connection.ClustersMgmt().V1().OidcConfigs().Add().Body(config).Send()
+	if err != nil {
+		glog.Errorf("Failed to create managed OIDC configuration: %v", err)
+		return nil, HandleOCMError(err)
+	}
+
+	created := response.Body()
+	glog.Infof("Successfully created managed OIDC configuration: %s", created.ID())
+	return created, nil
+}
+
+// DeleteOIDCConfig deletes an OIDC configuration by ID
+func (c *Client) DeleteOIDCConfig(oidcConfigID string) error {
+	if c.connection == nil {
+		return fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Deleting OIDC configuration: %s", oidcConfigID)
+	_, err := c.connection.ClustersMgmt().V1().OidcConfigs().OidcConfig(oidcConfigID).Delete().Send()
+	if err != nil {
+		glog.Errorf("Failed to delete OIDC configuration %s: %v", oidcConfigID, err)
+		return HandleOCMError(err)
+	}
+
+	glog.Infof("Successfully deleted OIDC configuration: %s", oidcConfigID)
+	return nil
+}
+
+// GetOIDCConfigUsage returns the clusters of the organization grouped by the ID of the OIDC
+// configuration they use. Clusters without an OIDC configuration are left out.
+func (c *Client) GetOIDCConfigUsage() (map[string][]*cmv1.Cluster, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Retrieving clusters to find OIDC configuration usage")
+	response, err := c.connection.ClustersMgmt().V1().Clusters().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list clusters: %v", err)
+		return nil, HandleOCMError(err)
+	}
+	return GroupClustersByOIDCConfig(response.Items().Slice()), nil
+}
+
+// GroupClustersByOIDCConfig groups clusters by the ID of the OIDC configuration they use
+func GroupClustersByOIDCConfig(clusters []*cmv1.Cluster) map[string][]*cmv1.Cluster {
+	usage := make(map[string][]*cmv1.Cluster)
+	for _, cluster := range clusters {
+		if id := clusterOIDCConfigID(cluster); id != "" {
+			usage[id] = append(usage[id], cluster)
+		}
+	}
+	return usage
+}
+
+// clusterOIDCConfigID returns the ID of the OIDC configuration a cluster uses, or "" if it has none
+func clusterOIDCConfigID(cluster *cmv1.Cluster) string {
+	aws := cluster.AWS()
+	if aws == nil || aws.STS() == nil || aws.STS().OidcConfig() == nil {
+		return ""
+	}
+	return aws.STS().OidcConfig().ID()
+}
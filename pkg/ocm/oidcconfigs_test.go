@@ -0,0 +1,33 @@
+package ocm
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupClustersByOIDCConfig(t *testing.T) {
+	build := func(id, oidcConfigID string) *cmv1.Cluster {
+		builder := cmv1.NewCluster().ID(id)
+		if oidcConfigID != "" {
+			builder = builder.AWS(cmv1.NewAWS().STS(cmv1.NewSTS().OidcConfig(cmv1.NewOidcConfig().ID(oidcConfigID))))
+		}
+		cluster, err := builder.Build()
+		assert.NoError(t, err)
+		return cluster
+	}
+
+	usage := GroupClustersByOIDCConfig([]*cmv1.Cluster{
+		build("cluster-a", "oidc-1"),
+		build("cluster-b", "oidc-2"),
+		build("cluster-c", "oidc-1"),
+		build("cluster-d", ""),
+	})
+
+	assert.Len(t, usage, 2)
+	assert.Len(t, usage["oidc-1"], 2)
+	assert.Equal(t, "cluster-a", usage["oidc-1"][0].ID())
+	assert.Equal(t, "cluster-c", usage["oidc-1"][1].ID())
+	assert.Len(t, usage["oidc-2"], 1)
+}
@@ -0,0 +1,280 @@
+package ocm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/tiwillia/rosa-mcp-go/pkg/validation"
+)
+
+const (
+	// operatorRolePolicyType is the OCM STS policy type of the operator role permission policies
+	operatorRolePolicyType = "OperatorRole"
+	// maxRoleNameLength is the longest IAM role name AWS accepts
+	maxRoleNameLength = 64
+)
+
+// terraformIdentifierRE matches the characters that are not allowed in Terraform resource names
+var terraformIdentifierRE = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// OperatorRolesSpec identifies the operator roles of a ROSA HCP cluster
+type OperatorRolesSpec struct {
+	Prefix        string
+	AccountID     string
+	OIDCIssuerURL string
+	// Version limits the roles to the operators of an OpenShift version; empty includes every operator
+	Version string
+}
+
+// Validate checks the spec
+func (s *OperatorRolesSpec) Validate() error {
+	if err := validation.ValidateOperatorRolePrefix(s.Prefix); err != nil {
+		return err
+	}
+	if err := validation.ValidateAWSAccountID(s.AccountID); err != nil {
+		return err
+	}
+	if err := validateHTTPSURL("OIDC issuer URL", s.OIDCIssuerURL); err != nil {
+		return err
+	}
+	if s.Version != "" {
+		if _, err := MinorVersion(s.Version); err != nil {
+			return fmt.Errorf("invalid version: %w", err)
+		}
+	}
+	return nil
+}
+
+// issuer returns the OIDC issuer without scheme and trailing slash, as used in IAM
+func (s *OperatorRolesSpec) issuer() string {
+	return strings.TrimSuffix(strings.TrimPrefix(s.OIDCIssuerURL, "https://"), "/")
+}
+
+// OIDCProviderARN returns the ARN of the IAM OIDC provider for the spec's issuer
+func (s *OperatorRolesSpec) OIDCProviderARN() string {
+	return fmt.Sprintf("arn:aws:iam::%s:oidc-provider/%s", s.AccountID, s.issuer())
+}
+
+// OperatorRole is an IAM role an operator of a ROSA HCP cluster assumes through the cluster's OIDC provider
+type OperatorRole struct {
+	Namespace       string
+	OperatorName    string
+	ServiceAccounts []string
+	RoleName        string
+	RoleARN         string
+	// TrustPolicy is the assume-role policy document, as indented JSON
+	TrustPolicy string
+	PolicyARNs  []string
+	Tags        map[string]string
+}
+
+// GetOperatorRoles reads the operator credential requests and operator policies from OCM and
+// returns the IAM roles a ROSA HCP cluster with the given spec needs
+func (c *Client) GetOperatorRoles(spec *OperatorRolesSpec) ([]*OperatorRole, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	glog.V(2).Infof("Retrieving ROSA HCP operator credential requests")
+	credRequests, err := c.connection.ClustersMgmt().V1().AWSInquiries().
+		STSCredentialRequests().
+		List().Parameter("is_hypershift", true).Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list STS credential requests: %v", err)
+		return nil, HandleOCMError(err)
+	}
+
+	glog.V(2).Infof("Retrieving operator role policies")
+	policies, err := c.connection.ClustersMgmt().V1().AWSInquiries().
+		STSPolicies().
+		List().Search(fmt.Sprintf("policy_type = '%s'", operatorRolePolicyType)).Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list STS policies: %v", err)
+		return nil, HandleOCMError(err)
+	}
+
+	return BuildOperatorRoles(spec, credRequests.Items().Slice(), policies.Items().Slice())
+}
+
+// BuildOperatorRoles returns the operator roles for the credential requests that apply to the spec's
+// version, sorted by role name. The permission policies are the managed HCP policies keyed
+// openshift_hcp_<credential request>_policy, as used by the rosa CLI.
+func BuildOperatorRoles(spec *OperatorRolesSpec, credRequests []*cmv1.STSCredentialRequest, policies []*cmv1.AWSSTSPolicy) ([]*OperatorRole, error) {
+	policyARNs := make(map[string]string, len(policies))
+	for _, policy := range policies {
+		policyARNs[policy.ID()] = policy.ARN()
+	}
+
+	var roles []*OperatorRole
+	for _, credRequest := range credRequests {
+		operator := credRequest.Operator()
+		if operator == nil || !operatorSupportsVersion(operator, spec.Version) {
+			continue
+		}
+
+		policyKey := fmt.Sprintf("openshift_hcp_%s_policy", credRequest.Name())
+		policyARN := policyARNs[policyKey]
+		if policyARN == "" {
+			return nil, fmt.Errorf("OCM has no managed policy '%s' for operator %s/%s", policyKey, operator.Namespace(), operator.Name())
+		}
+
+		trustPolicy, err := operatorRoleTrustPolicy(spec, operator)
+		if err != nil {
+			return nil, err
+		}
+
+		roleName := fmt.Sprintf("%s-%s-%s", spec.Prefix, operator.Namespace(), operator.Name())
+		if len(roleName) > maxRoleNameLength {
+			roleName = roleName[:maxRoleNameLength]
+		}
+
+		roles = append(roles, &OperatorRole{
+			Namespace:       operator.Namespace(),
+			OperatorName:    operator.Name(),
+			ServiceAccounts: operator.ServiceAccounts(),
+			RoleName:        roleName,
+			RoleARN:         fmt.Sprintf("arn:aws:iam::%s:role/%s", spec.AccountID, roleName),
+			TrustPolicy:     trustPolicy,
+			PolicyARNs:      []string{policyARN},
+			Tags: map[string]string{
+				"rosa_role_prefix":      spec.Prefix,
+				"operator_namespace":    operator.Namespace(),
+				"operator_name":         operator.Name(),
+				"rosa_managed_policies": "true",
+				"rosa_hcp_policies":     "true",
+				"red-hat-managed":       "true",
+			},
+		})
+	}
+
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].RoleName < roles[j].RoleName
+	})
+	return roles, nil
+}
+
+// operatorSupportsVersion reports whether an operator runs on clusters of the given version
+func operatorSupportsVersion(operator *cmv1.STSOperator, version string) bool {
+	if version == "" {
+		return true
+	}
+	// Operator version bounds are minor versions such as 4.12, so compare minor versions only
+	minor, err := MinorVersion(version)
+	if err != nil {
+		return true
+	}
+	if operator.MinVersion() != "" && CompareVersions(minor, operator.MinVersion()) < 0 {
+		return false
+	}
+	if operator.MaxVersion() != "" && CompareVersions(minor, operator.MaxVersion()) > 0 {
+		return false
+	}
+	return true
+}
+
+// operatorRoleTrustPolicy returns the trust policy that lets the operator's service accounts assume
+// the role with a web identity token issued by the cluster's OIDC provider
+func operatorRoleTrustPolicy(spec *OperatorRolesSpec, operator *cmv1.STSOperator) (string, error) {
+	subjects := make([]string, 0, len(operator.ServiceAccounts()))
+	for _, serviceAccount := range operator.ServiceAccounts() {
+		subjects = append(subjects, fmt.Sprintf("system:serviceaccount:%s:%s", operator.Namespace(), serviceAccount))
+	}
+
+	document := map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []interface{}{
+			map[string]interface{}{
+				"Effect":    "Allow",
+				"Principal": map[string]string{"Federated": spec.OIDCProviderARN()},
+				"Action":    "sts:AssumeRoleWithWebIdentity",
+				"Condition": map[string]interface{}{
+					"StringEquals": map[string][]string{
+						spec.issuer() + ":sub": subjects,
+					},
+				},
+			},
+		},
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to render trust policy: %w", err)
+	}
+	return string(data), nil
+}
+
+// AWSCLICommands returns the aws iam commands that create the role and attach its permission policies
+func (r *OperatorRole) AWSCLICommands() ([]string, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(r.TrustPolicy)); err != nil {
+		return nil, fmt.Errorf("invalid trust policy for role %s: %w", r.RoleName, err)
+	}
+
+	tags := make([]string, 0, len(r.Tags))
+	for _, key := range sortedKeys(r.Tags) {
+		tags = append(tags, fmt.Sprintf("Key=%s,Value=%s", key, r.Tags[key]))
+	}
+
+	commands := []string{fmt.Sprintf("aws iam create-role --role-name %s --assume-role-policy-document '%s' --tags %s",
+		r.RoleName, compact.String(), strings.Join(tags, " "))}
+	for _, policyARN := range r.PolicyARNs {
+		commands = append(commands, fmt.Sprintf("aws iam attach-role-policy --role-name %s --policy-arn %s", r.RoleName, policyARN))
+	}
+	return commands, nil
+}
+
+// OperatorRolesTerraform returns Terraform resources that create the roles and attach their permission policies
+func OperatorRolesTerraform(roles []*OperatorRole) string {
+	var blocks []string
+	for _, role := range roles {
+		resource := terraformIdentifierRE.ReplaceAllString(fmt.Sprintf("%s_%s", role.Namespace, role.OperatorName), "_")
+
+		var tags []string
+		for _, key := range sortedKeys(role.Tags) {
+			tags = append(tags, fmt.Sprintf("    %q = %q", key, role.Tags[key]))
+		}
+
+		blocks = append(blocks, fmt.Sprintf(`resource "aws_iam_role" %q {
+  name               = %q
+  assume_role_policy = <<-EOT
+%s
+  EOT
+
+  tags = {
+%s
+  }
+}`, resource, role.RoleName, indent(role.TrustPolicy, "    "), strings.Join(tags, "\n")))
+
+		for i, policyARN := range role.PolicyARNs {
+			name := resource
+			if i > 0 {
+				name = fmt.Sprintf("%s_%d", resource, i)
+			}
+			blocks = append(blocks, fmt.Sprintf(`resource "aws_iam_role_policy_attachment" %q {
+  role       = aws_iam_role.%s.name
+  policy_arn = %q
+}`, name, resource, policyARN))
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// indent prefixes every line of text with prefix
+func indent(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,155 @@
+package ocm
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testOperatorRolesSpec returns a spec without problems
+func testOperatorRolesSpec() *OperatorRolesSpec {
+	return &OperatorRolesSpec{
+		Prefix:        "my-cluster",
+		AccountID:     "123456789012",
+		OIDCIssuerURL: "https://oidc.os1.devshift.org/abc123/",
+	}
+}
+
+// testCredentialRequest builds an operator credential request as returned by OCM
+func testCredentialRequest(t *testing.T, name, namespace, operator, minVersion string, serviceAccounts ...string) *cmv1.STSCredentialRequest {
+	credRequest, err := cmv1.NewSTSCredentialRequest().
+		Name(name).
+		Operator(cmv1.NewSTSOperator().
+			Name(operator).
+			Namespace(namespace).
+			MinVersion(minVersion).
+			ServiceAccounts(serviceAccounts...)).
+		Build()
+	assert.NoError(t, err)
+	return credRequest
+}
+
+// testOperatorPolicies builds the managed operator policies for the given credential request names
+func testOperatorPolicies(t *testing.T, names ...string) []*cmv1.AWSSTSPolicy {
+	var policies []*cmv1.AWSSTSPolicy
+	for _, name := range names {
+		policy, err := cmv1.NewAWSSTSPolicy().
+			ID("openshift_hcp_" + name + "_policy").
+			ARN("arn:aws:iam::aws:policy/service-role/ROSA" + name).
+			Type(operatorRolePolicyType).
+			Build()
+		assert.NoError(t, err)
+		policies = append(policies, policy)
+	}
+	return policies
+}
+
+func TestOperatorRolesSpecValidate(t *testing.T) {
+	assert.NoError(t, testOperatorRolesSpec().Validate())
+
+	spec := testOperatorRolesSpec()
+	spec.Prefix = "my/prefix"
+	assert.Error(t, spec.Validate())
+
+	spec = testOperatorRolesSpec()
+	spec.AccountID = "1234"
+	assert.Error(t, spec.Validate())
+
+	spec = testOperatorRolesSpec()
+	spec.OIDCIssuerURL = "http://oidc.example.com"
+	assert.Error(t, spec.Validate())
+
+	spec = testOperatorRolesSpec()
+	spec.Version = "latest"
+	assert.Error(t, spec.Validate())
+
+	assert.Equal(t, "arn:aws:iam::123456789012:oidc-provider/oidc.os1.devshift.org/abc123", testOperatorRolesSpec().OIDCProviderARN())
+}
+
+func TestBuildOperatorRoles(t *testing.T) {
+	credRequests := []*cmv1.STSCredentialRequest{
+		testCredentialRequest(t, "kube_controller_manager", "kube-system", "kube-controller-manager", "", "kube-controller-manager"),
+		testCredentialRequest(t, "ingress", "openshift-ingress-operator", "cloud-credentials", "", "ingress-operator"),
+		testCredentialRequest(t, "kms_provider", "kube-system", "kms-provider", "4.17", "kms-provider"),
+	}
+	policies := testOperatorPolicies(t, "kube_controller_manager", "ingress", "kms_provider")
+
+	roles, err := BuildOperatorRoles(testOperatorRolesSpec(), credRequests, policies)
+	assert.NoError(t, err)
+	assert.Len(t, roles, 3)
+
+	role := roles[1]
+	assert.Equal(t, "my-cluster-kube-system-kube-controller-manager", role.RoleName)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/my-cluster-kube-system-kube-controller-manager", role.RoleARN)
+	assert.Equal(t, []string{"arn:aws:iam::aws:policy/service-role/ROSAkube_controller_manager"}, role.PolicyARNs)
+	assert.Equal(t, "my-cluster", role.Tags["rosa_role_prefix"])
+
+	var trust struct {
+		Statement []struct {
+			Principal map[string]string
+			Action    string
+			Condition map[string]map[string][]string
+		}
+	}
+	assert.NoError(t, json.Unmarshal([]byte(role.TrustPolicy), &trust))
+	assert.Len(t, trust.Statement, 1)
+	assert.Equal(t, "arn:aws:iam::123456789012:oidc-provider/oidc.os1.devshift.org/abc123", trust.Statement[0].Principal["Federated"])
+	assert.Equal(t, "sts:AssumeRoleWithWebIdentity", trust.Statement[0].Action)
+	assert.Equal(t, []string{"system:serviceaccount:kube-system:kube-controller-manager"},
+		trust.Statement[0].Condition["StringEquals"]["oidc.os1.devshift.org/abc123:sub"])
+
+	// Operators that require a newer version are left out
+	spec := testOperatorRolesSpec()
+	spec.Version = "4.16.8"
+	roles, err = BuildOperatorRoles(spec, credRequests, policies)
+	assert.NoError(t, err)
+	assert.Len(t, roles, 2)
+
+	spec.Version = "4.17.0"
+	roles, err = BuildOperatorRoles(spec, credRequests, policies)
+	assert.NoError(t, err)
+	assert.Len(t, roles, 3)
+
+	// Every operator needs a managed policy
+	_, err = BuildOperatorRoles(testOperatorRolesSpec(), credRequests, policies[:2])
+	assert.ErrorContains(t, err, "openshift_hcp_kms_provider_policy")
+}
+
+func TestBuildOperatorRolesTruncatesRoleNames(t *testing.T) {
+	spec := testOperatorRolesSpec()
+	spec.Prefix = strings.Repeat("p", 32)
+	credRequests := []*cmv1.STSCredentialRequest{
+		testCredentialRequest(t, "image_registry", "openshift-image-registry", "installer-cloud-credentials", "", "registry"),
+	}
+
+	roles, err := BuildOperatorRoles(spec, credRequests, testOperatorPolicies(t, "image_registry"))
+	assert.NoError(t, err)
+	assert.Len(t, roles[0].RoleName, maxRoleNameLength)
+	assert.True(t, strings.HasSuffix(roles[0].RoleARN, ":role/"+roles[0].RoleName))
+}
+
+func TestOperatorRoleExports(t *testing.T) {
+	credRequests := []*cmv1.STSCredentialRequest{
+		testCredentialRequest(t, "ingress", "openshift-ingress-operator", "cloud-credentials", "", "ingress-operator"),
+	}
+	roles, err := BuildOperatorRoles(testOperatorRolesSpec(), credRequests, testOperatorPolicies(t, "ingress"))
+	assert.NoError(t, err)
+
+	commands, err := roles[0].AWSCLICommands()
+	assert.NoError(t, err)
+	assert.Len(t, commands, 2)
+	assert.True(t, strings.HasPrefix(commands[0], "aws iam create-role --role-name my-cluster-openshift-ingress-operator-cloud-credentials --assume-role-policy-document '{\"Statement\":"))
+	assert.Contains(t, commands[0], "Key=rosa_role_prefix,Value=my-cluster")
+	assert.NotContains(t, commands[0], "\n")
+	assert.Equal(t, "aws iam attach-role-policy --role-name my-cluster-openshift-ingress-operator-cloud-credentials --policy-arn arn:aws:iam::aws:policy/service-role/ROSAingress", commands[1])
+
+	terraform := OperatorRolesTerraform(roles)
+	assert.Contains(t, terraform, `resource "aws_iam_role" "openshift_ingress_operator_cloud_credentials" {`)
+	assert.Contains(t, terraform, `name               = "my-cluster-openshift-ingress-operator-cloud-credentials"`)
+	assert.Contains(t, terraform, `"sts:AssumeRoleWithWebIdentity"`)
+	assert.Contains(t, terraform, `role       = aws_iam_role.openshift_ingress_operator_cloud_credentials.name`)
+	assert.Contains(t, terraform, `policy_arn = "arn:aws:iam::aws:policy/service-role/ROSAingress"`)
+}
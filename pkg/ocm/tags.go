@@ -0,0 +1,84 @@
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// CreatorARNProperty is the cluster property recording the IAM identity that created the cluster
+	CreatorARNProperty = "rosa_creator_arn"
+
+	// maxAWSTags is the number of user tags ROSA accepts; the remaining AWS tag slots are used by OpenShift
+	maxAWSTags        = 25
+	maxAWSTagKeyLen   = 128
+	maxAWSTagValueLen = 256
+)
+
+// awsTagRE matches the characters AWS allows in tag keys and values
+var awsTagRE = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+
+// clusterPropertyKeyRE matches the keys accepted for OCM cluster properties
+var clusterPropertyKeyRE = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.\-]*$`)
+
+// reservedAWSTagPrefixes are tag key prefixes owned by AWS and Red Hat
+var reservedAWSTagPrefixes = []string{"aws:", "red-hat-"}
+
+// reservedClusterProperties are set by this server and cannot be overridden
+var reservedClusterProperties = []string{CreatorARNProperty}
+
+// ValidateAWSTags checks user tags against the AWS tag rules and the prefixes reserved for AWS and Red Hat
+func ValidateAWSTags(tags map[string]string) error {
+	if len(tags) > maxAWSTags {
+		return fmt.Errorf("too many AWS tags: at most %d are allowed, got %d", maxAWSTags, len(tags))
+	}
+
+	for _, key := range sortedKeys(tags) {
+		value := tags[key]
+		if key == "" || utf8.RuneCountInString(key) > maxAWSTagKeyLen {
+			return fmt.Errorf("invalid AWS tag key '%s': must be 1-%d characters", key, maxAWSTagKeyLen)
+		}
+		if !awsTagRE.MatchString(key) {
+			return fmt.Errorf("invalid AWS tag key '%s': only letters, numbers, spaces and _ . : / = + - @ are allowed", key)
+		}
+		for _, prefix := range reservedAWSTagPrefixes {
+			if strings.HasPrefix(strings.ToLower(key), prefix) {
+				return fmt.Errorf("invalid AWS tag key '%s': the %s prefix is reserved", key, prefix)
+			}
+		}
+		if utf8.RuneCountInString(value) > maxAWSTagValueLen {
+			return fmt.Errorf("invalid value for AWS tag '%s': must be at most %d characters", key, maxAWSTagValueLen)
+		}
+		if !awsTagRE.MatchString(value) {
+			return fmt.Errorf("invalid value for AWS tag '%s': only letters, numbers, spaces and _ . : / = + - @ are allowed", key)
+		}
+	}
+	return nil
+}
+
+// ValidateClusterProperties checks extra OCM cluster properties; properties set by this server cannot be overridden
+func ValidateClusterProperties(properties map[string]string) error {
+	for _, key := range sortedKeys(properties) {
+		if !clusterPropertyKeyRE.MatchString(key) {
+			return fmt.Errorf("invalid property key '%s': only letters, numbers and _ . - are allowed", key)
+		}
+		if slices.Contains(reservedClusterProperties, key) {
+			return fmt.Errorf("property '%s' is set automatically and cannot be overridden", key)
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in sorted order, so that validation errors are deterministic
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
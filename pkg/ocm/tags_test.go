@@ -0,0 +1,69 @@
+package ocm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAWSTags(t *testing.T) {
+	tooMany := make(map[string]string, maxAWSTags+1)
+	for i := 0; i <= maxAWSTags; i++ {
+		tooMany[fmt.Sprintf("tag-%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name    string
+		tags    map[string]string
+		wantErr bool
+	}{
+		{name: "no tags", tags: nil},
+		{name: "valid tags", tags: map[string]string{"cost-centre": "1234", "owner": "team@example.com", "app:tier": "web / api"}},
+		{name: "empty value", tags: map[string]string{"owner": ""}},
+		{name: "unicode letters", tags: map[string]string{"équipe": "données"}},
+		{name: "empty key", tags: map[string]string{"": "value"}, wantErr: true},
+		{name: "key too long", tags: map[string]string{strings.Repeat("k", maxAWSTagKeyLen+1): "value"}, wantErr: true},
+		{name: "value too long", tags: map[string]string{"owner": strings.Repeat("v", maxAWSTagValueLen+1)}, wantErr: true},
+		{name: "invalid key character", tags: map[string]string{"owner#": "value"}, wantErr: true},
+		{name: "invalid value character", tags: map[string]string{"owner": "a,b"}, wantErr: true},
+		{name: "aws prefix", tags: map[string]string{"aws:createdBy": "me"}, wantErr: true},
+		{name: "red hat prefix", tags: map[string]string{"red-hat-managed": "false"}, wantErr: true},
+		{name: "red hat prefix any case", tags: map[string]string{"Red-Hat-ClusterType": "rosa"}, wantErr: true},
+		{name: "too many tags", tags: tooMany, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAWSTags(tt.tags)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateClusterProperties(t *testing.T) {
+	assert.NoError(t, ValidateClusterProperties(nil))
+	assert.NoError(t, ValidateClusterProperties(map[string]string{"cost_centre": "1234", "team.name": "platform"}))
+	assert.Error(t, ValidateClusterProperties(map[string]string{CreatorARNProperty: "arn:aws:iam::123456789012:user/other"}))
+	assert.Error(t, ValidateClusterProperties(map[string]string{"has space": "value"}))
+	assert.Error(t, ValidateClusterProperties(map[string]string{"": "value"}))
+}
+
+func TestClusterCreateSpecTagsAndProperties(t *testing.T) {
+	spec := testClusterCreateSpec()
+	spec.AWSTags = map[string]string{"cost-centre": "1234"}
+	spec.Properties = map[string]string{"team": "platform"}
+	assert.NoError(t, spec.Validate())
+
+	cluster, err := spec.build()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"cost-centre": "1234"}, cluster.AWS().Tags())
+	assert.Equal(t, map[string]string{"team": "platform", CreatorARNProperty: spec.CreatorARN}, cluster.Properties())
+
+	spec.AWSTags = map[string]string{"red-hat-managed": "true"}
+	assert.Error(t, spec.Validate())
+}
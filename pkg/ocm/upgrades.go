@@ -0,0 +1,317 @@
+package ocm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// ManualUpgradeLeadTime is how far in the future an "upgrade now" policy is scheduled,
+// giving OCM time to run its pre-upgrade checks
+const ManualUpgradeLeadTime = 10 * time.Minute
+
+// UpgradeScheduleSpec describes when and to which version an upgrade policy runs.
+// A non-empty Schedule creates a recurring (automatic) policy; otherwise a manual
+// policy is created for Version at NextRun.
+type UpgradeScheduleSpec struct {
+	Version  string
+	NextRun  time.Time
+	Schedule string
+}
+
+// Validate checks that the spec describes either a manual or a recurring upgrade
+func (s *UpgradeScheduleSpec) Validate() error {
+	if s.Schedule != "" {
+		if s.Version != "" {
+			return fmt.Errorf("recurring upgrades follow the latest available patch version; version must not be set")
+		}
+		if fields := strings.Fields(s.Schedule); len(fields) != 5 {
+			return fmt.Errorf("invalid cron schedule '%s': expected 5 fields (minute hour day-of-month month day-of-week)", s.Schedule)
+		}
+		return nil
+	}
+	if s.Version == "" {
+		return fmt.Errorf("version is required for a manual upgrade")
+	}
+	if s.NextRun.IsZero() {
+		return fmt.Errorf("an upgrade time is required for a manual upgrade")
+	}
+	return nil
+}
+
+// scheduleType returns the OCM schedule type for the spec
+func (s *UpgradeScheduleSpec) scheduleType() cmv1.ScheduleType {
+	if s.Schedule != "" {
+		return cmv1.ScheduleTypeAutomatic
+	}
+	return cmv1.ScheduleTypeManual
+}
+
+// GetControlPlaneUpgradePolicies returns the control plane upgrade policies of a cluster
+func (c *Client) GetControlPlaneUpgradePolicies(clusterID string) ([]*cmv1.ControlPlaneUpgradePolicy, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Retrieving control plane upgrade policies for cluster: %s", clusterID)
+	response, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		ControlPlane().UpgradePolicies().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to get control plane upgrade policies for cluster %s: %v", clusterID, err)
+		return nil, HandleOCMError(err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// GetNodePoolUpgradePolicies returns the upgrade policies of a node pool
+func (c *Client) GetNodePoolUpgradePolicies(clusterID, nodePoolID string) ([]*cmv1.NodePoolUpgradePolicy, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Retrieving upgrade policies for node pool %s on cluster: %s", nodePoolID, clusterID)
+	response, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		NodePools().NodePool(nodePoolID).
+		UpgradePolicies().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to get upgrade policies for node pool %s on cluster %s: %v", nodePoolID, clusterID, err)
+		return nil, HandleOCMError(err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// CreateControlPlaneUpgradePolicy schedules a control plane upgrade
+func (c *Client) CreateControlPlaneUpgradePolicy(clusterID string, spec *UpgradeScheduleSpec) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	builder := cmv1.NewControlPlaneUpgradePolicy().
+		UpgradeType(cmv1.UpgradeTypeControlPlane).
+		ScheduleType(spec.scheduleType())
+	if spec.Schedule != "" {
+		builder = builder.Schedule(spec.Schedule)
+	} else {
+		builder = builder.Version(spec.Version).NextRun(spec.NextRun)
+	}
+
+	policy, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build upgrade policy: %w", err)
+	}
+
+	glog.V(2).Infof("Creating control plane upgrade policy for cluster %s", clusterID)
+	response, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		ControlPlane().UpgradePolicies().
+		Add().Body(policy).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to create control plane upgrade policy for cluster %s: %v", clusterID, err)
+		return nil, HandleOCMError(err)
+	}
+
+	glog.Infof("Successfully scheduled control plane upgrade for cluster %s", clusterID)
+	return response.Body(), nil
+}
+
+// CreateNodePoolUpgradePolicy schedules a node pool upgrade
+func (c *Client) CreateNodePoolUpgradePolicy(clusterID, nodePoolID string, spec *UpgradeScheduleSpec) (*cmv1.NodePoolUpgradePolicy, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	builder := cmv1.NewNodePoolUpgradePolicy().
+		NodePoolID(nodePoolID).
+		UpgradeType(cmv1.UpgradeTypeNodePool).
+		ScheduleType(spec.scheduleType())
+	if spec.Schedule != "" {
+		builder = builder.Schedule(spec.Schedule)
+	} else {
+		builder = builder.Version(spec.Version).NextRun(spec.NextRun)
+	}
+
+	policy, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build upgrade policy: %w", err)
+	}
+
+	glog.V(2).Infof("Creating upgrade policy for node pool %s on cluster %s", nodePoolID, clusterID)
+	response, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		NodePools().NodePool(nodePoolID).
+		UpgradePolicies().
+		Add().Body(policy).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to create upgrade policy for node pool %s on cluster %s: %v", nodePoolID, clusterID, err)
+		return nil, HandleOCMError(err)
+	}
+
+	glog.Infof("Successfully scheduled upgrade for node pool %s on cluster %s", nodePoolID, clusterID)
+	return response.Body(), nil
+}
+
+// CancelControlPlaneUpgradePolicy deletes a scheduled control plane upgrade policy
+func (c *Client) CancelControlPlaneUpgradePolicy(clusterID, policyID string) error {
+	if c.connection == nil {
+		return fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Cancelling control plane upgrade policy %s for cluster %s", policyID, clusterID)
+	_, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		ControlPlane().UpgradePolicies().
+		ControlPlaneUpgradePolicy(policyID).
+		Delete().
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to cancel control plane upgrade policy %s for cluster %s: %v", policyID, clusterID, err)
+		return HandleOCMError(err)
+	}
+	return nil
+}
+
+// CancelNodePoolUpgradePolicy deletes a scheduled node pool upgrade policy
+func (c *Client) CancelNodePoolUpgradePolicy(clusterID, nodePoolID, policyID string) error {
+	if c.connection == nil {
+		return fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Cancelling upgrade policy %s for node pool %s on cluster %s", policyID, nodePoolID, clusterID)
+	_, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		NodePools().NodePool(nodePoolID).
+		UpgradePolicies().NodePoolUpgradePolicy(policyID).
+		Delete().
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to cancel upgrade policy %s for node pool %s on cluster %s: %v", policyID, nodePoolID, clusterID, err)
+		return HandleOCMError(err)
+	}
+	return nil
+}
+
+// GetMissingVersionGates returns the version gates between the cluster's current version and
+// targetVersion that have not yet been acknowledged for the cluster
+func (c *Client) GetMissingVersionGates(cluster *cmv1.Cluster, targetVersion string) ([]*cmv1.VersionGate, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	currentMinor, err := MinorVersion(cluster.Version().RawID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine current cluster version: %w", err)
+	}
+	targetMinor, err := MinorVersion(targetVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gates only apply to minor version upgrades
+	if CompareVersions(targetMinor, currentMinor) <= 0 {
+		return nil, nil
+	}
+
+	glog.V(2).Infof("Checking version gates for cluster %s (%s -> %s)", cluster.ID(), currentMinor, targetMinor)
+	gatesResponse, err := c.connection.ClustersMgmt().V1().
+		VersionGates().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list version gates: %v", err)
+		return nil, HandleOCMError(err)
+	}
+
+	agreementsResponse, err := c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(cluster.ID()).
+		GateAgreements().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list version gate agreements for cluster %s: %v", cluster.ID(), err)
+		return nil, HandleOCMError(err)
+	}
+
+	acknowledged := make(map[string]bool)
+	agreementsResponse.Items().Each(func(agreement *cmv1.VersionGateAgreement) bool {
+		if gate := agreement.VersionGate(); gate != nil {
+			acknowledged[gate.ID()] = true
+		}
+		return true
+	})
+
+	isSTS := cluster.AWS() != nil && cluster.AWS().STS() != nil && cluster.AWS().STS().RoleARN() != ""
+
+	missing := make([]*cmv1.VersionGate, 0)
+	gatesResponse.Items().Each(func(gate *cmv1.VersionGate) bool {
+		prefix := gate.VersionRawIDPrefix()
+		if CompareVersions(prefix, currentMinor) <= 0 || CompareVersions(prefix, targetMinor) > 0 {
+			return true
+		}
+		if gate.STSOnly() && !isSTS {
+			return true
+		}
+		if !acknowledged[gate.ID()] {
+			missing = append(missing, gate)
+		}
+		return true
+	})
+
+	glog.V(2).Infof("Found %d unacknowledged version gates for cluster %s", len(missing), cluster.ID())
+	return missing, nil
+}
+
+// AcknowledgeVersionGate records the cluster owner's agreement to a version gate
+func (c *Client) AcknowledgeVersionGate(clusterID, gateID string) error {
+	if c.connection == nil {
+		return fmt.Errorf("client not authenticated")
+	}
+
+	agreement, err := cmv1.NewVersionGateAgreement().
+		VersionGate(cmv1.NewVersionGate().ID(gateID)).
+		Build()
+	if err != nil {
+		return fmt.Errorf("failed to build version gate agreement: %w", err)
+	}
+
+	glog.V(2).Infof("Acknowledging version gate %s for cluster %s", gateID, clusterID)
+	_, err = c.connection.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		GateAgreements().
+		Add().Body(agreement).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to acknowledge version gate %s for cluster %s: %v", gateID, clusterID, err)
+		return HandleOCMError(err)
+	}
+	return nil
+}
+
+// NodePoolAvailableUpgrades returns the versions a node pool can be upgraded to. Node pools
+// cannot run a newer version than the control plane, so later versions are excluded.
+func NodePoolAvailableUpgrades(cluster *cmv1.Cluster, nodePool *cmv1.NodePool) []string {
+	controlPlaneVersion := cluster.Version().RawID()
+	versions := make([]string, 0)
+	for _, version := range nodePool.Version().AvailableUpgrades() {
+		if controlPlaneVersion == "" || CompareVersions(version, controlPlaneVersion) <= 0 {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
@@ -0,0 +1,133 @@
+package ocm
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// DefaultChannelGroup is the channel group new clusters are installed from
+const DefaultChannelGroup = "stable"
+
+// ValidChannelGroups lists the channel groups versions can be listed from
+var ValidChannelGroups = []string{"stable", "candidate", "fast"}
+
+// parseVersion splits an OpenShift raw version such as 4.16.3 or 4.17.0-rc.1 into its
+// numeric components and optional pre-release suffix
+func parseVersion(version string) ([]int, string, error) {
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "openshift-"), "v")
+	core, preRelease, _ := strings.Cut(version, "-")
+
+	fields := strings.Split(core, ".")
+	numbers := make([]int, 0, len(fields))
+	for _, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid version '%s'", version)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, preRelease, nil
+}
+
+// CompareVersions compares two OpenShift versions semver-style.
+// It returns -1 if a < b, 0 if a == b and 1 if a > b. Unparseable versions sort lexically.
+func CompareVersions(a, b string) int {
+	aNumbers, aPre, aErr := parseVersion(a)
+	bNumbers, bPre, bErr := parseVersion(b)
+	if aErr != nil || bErr != nil {
+		return strings.Compare(a, b)
+	}
+
+	for i := 0; i < len(aNumbers) || i < len(bNumbers); i++ {
+		var x, y int
+		if i < len(aNumbers) {
+			x = aNumbers[i]
+		}
+		if i < len(bNumbers) {
+			y = bNumbers[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A pre-release sorts before the release it precedes
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	default:
+		return strings.Compare(aPre, bPre)
+	}
+}
+
+// MinorVersion returns the major.minor prefix of a version, e.g. 4.16 for 4.16.3
+func MinorVersion(version string) (string, error) {
+	numbers, _, err := parseVersion(version)
+	if err != nil {
+		return "", err
+	}
+	if len(numbers) < 2 {
+		return "", fmt.Errorf("invalid version '%s': expected at least major.minor", version)
+	}
+	return fmt.Sprintf("%d.%d", numbers[0], numbers[1]), nil
+}
+
+// ListVersions returns the enabled OpenShift versions that ROSA HCP clusters can be installed with,
+// newest first. An empty channel group lists the versions of every channel group.
+func (c *Client) ListVersions(channelGroup string) ([]*cmv1.Version, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+	if channelGroup != "" && !slices.Contains(ValidChannelGroups, channelGroup) {
+		return nil, fmt.Errorf("invalid channel group '%s': must be one of %s", channelGroup, strings.Join(ValidChannelGroups, ", "))
+	}
+
+	glog.V(2).Infof("Retrieving ROSA HCP versions in channel group: %s", channelGroup)
+	response, err := c.connection.ClustersMgmt().V1().Versions().
+		List().
+		Search(versionSearch(channelGroup)).
+		Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to list versions: %v", err)
+		return nil, HandleOCMError(err)
+	}
+
+	versions := response.Items().Slice()
+	SortVersions(versions)
+	glog.V(2).Infof("Retrieved %d versions", len(versions))
+	return versions, nil
+}
+
+// versionSearch returns the OCM search query for the enabled ROSA HCP versions of a channel group
+func versionSearch(channelGroup string) string {
+	search := "enabled = 'true' AND rosa_enabled = 'true' AND hosted_control_plane_enabled = 'true'"
+	if channelGroup != "" {
+		search += fmt.Sprintf(" AND channel_group = '%s'", channelGroup)
+	}
+	return search
+}
+
+// SortVersions sorts versions newest first by their raw version. Versions of the same release in
+// different channel groups are ordered by channel group name.
+func SortVersions(versions []*cmv1.Version) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		if cmp := CompareVersions(versions[i].RawID(), versions[j].RawID()); cmp != 0 {
+			return cmp > 0
+		}
+		return versions[i].ChannelGroup() < versions[j].ChannelGroup()
+	})
+}
@@ -0,0 +1,71 @@
+package ocm
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "4.16.3", b: "4.16.3", expected: 0},
+		{a: "4.16.3", b: "4.16.10", expected: -1},
+		{a: "4.17.0", b: "4.16.10", expected: 1},
+		{a: "4.17.0-rc.1", b: "4.17.0", expected: -1},
+		{a: "4.17.0-rc.2", b: "4.17.0-rc.1", expected: 1},
+		{a: "openshift-v4.16.3", b: "4.16.3", expected: 0},
+		{a: "4.9.0", b: "4.10.0", expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CompareVersions(tt.a, tt.b))
+		})
+	}
+}
+
+func TestMinorVersion(t *testing.T) {
+	minor, err := MinorVersion("4.16.3")
+	assert.NoError(t, err)
+	assert.Equal(t, "4.16", minor)
+
+	minor, err = MinorVersion("openshift-v4.17.0-rc.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4.17", minor)
+
+	_, err = MinorVersion("latest")
+	assert.Error(t, err)
+}
+
+func TestSortVersions(t *testing.T) {
+	var versions []*cmv1.Version
+	for _, v := range []struct{ rawID, channelGroup string }{
+		{"4.9.0", "stable"},
+		{"4.17.0-rc.1", "candidate"},
+		{"4.16.10", "stable"},
+		{"4.17.0", "stable"},
+		{"4.16.10", "fast"},
+		{"4.16.3", "stable"},
+	} {
+		version, err := cmv1.NewVersion().RawID(v.rawID).ChannelGroup(v.channelGroup).Build()
+		assert.NoError(t, err)
+		versions = append(versions, version)
+	}
+
+	SortVersions(versions)
+
+	var sorted []string
+	for _, version := range versions {
+		sorted = append(sorted, version.RawID()+"/"+version.ChannelGroup())
+	}
+	assert.Equal(t, []string{"4.17.0/stable", "4.17.0-rc.1/candidate", "4.16.10/fast", "4.16.10/stable", "4.16.3/stable", "4.9.0/stable"}, sorted)
+}
+
+func TestVersionSearch(t *testing.T) {
+	assert.Equal(t, "enabled = 'true' AND rosa_enabled = 'true' AND hosted_control_plane_enabled = 'true'", versionSearch(""))
+	assert.Equal(t, "enabled = 'true' AND rosa_enabled = 'true' AND hosted_control_plane_enabled = 'true' AND channel_group = 'candidate'", versionSearch("candidate"))
+}
@@ -0,0 +1,185 @@
+package ocm
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/glog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	// ELBRoleTag marks the public subnets for internet-facing load balancers
+	ELBRoleTag = "kubernetes.io/role/elb"
+	// InternalELBRoleTag marks the private subnets for internal load balancers
+	InternalELBRoleTag = "kubernetes.io/role/internal-elb"
+	// maxAvailabilityZones is the largest number of availability zones a cluster is spread across
+	maxAvailabilityZones = 3
+)
+
+// GetSubnets looks up the given subnets through the OCM AWS VPC inquiry, which assumes the
+// installer role in the customer account. Subnets that are not found are omitted from the result.
+func (c *Client) GetSubnets(awsAccountID, installerRoleARN, region string, subnetIDs []string) ([]*cmv1.Subnetwork, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Looking up %d subnets in region %s", len(subnetIDs), region)
+	vpcs, err := c.searchVPCs(awsAccountID, installerRoleARN, region, subnetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(subnetIDs))
+	for _, subnetID := range subnetIDs {
+		wanted[subnetID] = true
+	}
+	subnets := make([]*cmv1.Subnetwork, 0, len(subnetIDs))
+	for _, vpc := range vpcs {
+		for _, subnet := range vpc.AWSSubnets() {
+			if wanted[subnet.SubnetID()] {
+				subnets = append(subnets, subnet)
+			}
+		}
+	}
+	return subnets, nil
+}
+
+// ListVPCs returns the VPCs of a region with their subnets, sorted by ID, through the OCM AWS
+// VPC inquiry, which assumes the installer role in the customer account
+func (c *Client) ListVPCs(awsAccountID, installerRoleARN, region string) ([]*cmv1.CloudVPC, error) {
+	if c.connection == nil {
+		return nil, fmt.Errorf("client not authenticated")
+	}
+
+	glog.V(2).Infof("Retrieving VPCs in region %s", region)
+	vpcs, err := c.searchVPCs(awsAccountID, installerRoleARN, region, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(vpcs, func(i, j int) bool {
+		return vpcs[i].ID() < vpcs[j].ID()
+	})
+	glog.V(2).Infof("Retrieved %d VPCs in region %s", len(vpcs), region)
+	return vpcs, nil
+}
+
+// searchVPCs runs the OCM AWS VPC inquiry for a region, limited to the VPCs of the given subnets
+// when there are any
+func (c *Client) searchVPCs(awsAccountID, installerRoleARN, region string, subnetIDs []string) ([]*cmv1.CloudVPC, error) {
+	body, err := cmv1.NewCloudProviderData().
+		AWS(cmv1.NewAWS().
+			AccountID(awsAccountID).
+			STS(cmv1.NewSTS().RoleARN(installerRoleARN))).
+		Region(cmv1.NewCloudRegion().ID(region)).
+		Subnets(subnetIDs...).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build VPC inquiry: %w", err)
+	}
+
+	response, err := c.connection.ClustersMgmt().V1().
+		AWSInquiries().Vpcs().
+		Search().Body(body).
+		Page(1).Size(-1).
+		Send()
+	if err != nil {
+		glog.Errorf("Failed to search VPCs in region %s: %v", region, err)
+		return nil, HandleOCMError(err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// RequiredELBRoleTag returns the tag AWS load balancer controllers need to pick a subnet: public
+// subnets host internet-facing load balancers and private subnets internal ones. It is derived from
+// whether the subnet is public, not read from AWS.
+func RequiredELBRoleTag(subnet *cmv1.Subnetwork) string {
+	if subnet.Public() {
+		return ELBRoleTag
+	}
+	return InternalELBRoleTag
+}
+
+// SubnetRecommendation is a set of subnets of one VPC for the subnet_ids and availability_zones of a cluster
+type SubnetRecommendation struct {
+	VPCID             string
+	Private           bool
+	SubnetIDs         []string
+	AvailabilityZones []string
+	// MachineCIDR is the machine_cidr the subnets need, empty when the default machine network contains them
+	MachineCIDR string
+}
+
+// RecommendSubnets picks a private subnet in each of up to zoneCount availability zones of the VPC,
+// plus a public subnet in each of those zones for public clusters. Only zones with every subnet the
+// cluster needs are used, in name order. A zoneCount of 0 uses up to three zones.
+func RecommendSubnets(vpc *cmv1.CloudVPC, private bool, zoneCount int) (*SubnetRecommendation, error) {
+	if zoneCount < 0 || zoneCount > maxAvailabilityZones {
+		return nil, fmt.Errorf("invalid zone count %d: must be between 1 and %d", zoneCount, maxAvailabilityZones)
+	}
+
+	privateByZone := make(map[string]*cmv1.Subnetwork)
+	publicByZone := make(map[string]*cmv1.Subnetwork)
+	subnets := append([]*cmv1.Subnetwork(nil), vpc.AWSSubnets()...)
+	sort.Slice(subnets, func(i, j int) bool {
+		return subnets[i].SubnetID() < subnets[j].SubnetID()
+	})
+	for _, subnet := range subnets {
+		// Subnets Red Hat manages belong to other clusters
+		if subnet.RedHatManaged() || subnet.AvailabilityZone() == "" {
+			continue
+		}
+		byZone := privateByZone
+		if subnet.Public() {
+			byZone = publicByZone
+		}
+		if _, ok := byZone[subnet.AvailabilityZone()]; !ok {
+			byZone[subnet.AvailabilityZone()] = subnet
+		}
+	}
+
+	var zones []string
+	for zone := range privateByZone {
+		if private || publicByZone[zone] != nil {
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	if len(zones) == 0 {
+		if private {
+			return nil, fmt.Errorf("VPC %s has no private subnets that are not managed by Red Hat", vpc.ID())
+		}
+		return nil, fmt.Errorf("VPC %s has no availability zone with both a private and a public subnet", vpc.ID())
+	}
+
+	limit := zoneCount
+	if limit == 0 {
+		limit = maxAvailabilityZones
+	}
+	if zoneCount > len(zones) {
+		return nil, fmt.Errorf("VPC %s has suitable subnets in %d availability zone(s), %d requested", vpc.ID(), len(zones), zoneCount)
+	}
+	if len(zones) > limit {
+		zones = zones[:limit]
+	}
+
+	recommendation := &SubnetRecommendation{VPCID: vpc.ID(), Private: private, AvailabilityZones: zones}
+	var chosen []*cmv1.Subnetwork
+	for _, zone := range zones {
+		chosen = append(chosen, privateByZone[zone])
+	}
+	if !private {
+		for _, zone := range zones {
+			chosen = append(chosen, publicByZone[zone])
+		}
+	}
+	for _, subnet := range chosen {
+		recommendation.SubnetIDs = append(recommendation.SubnetIDs, subnet.SubnetID())
+	}
+
+	if err := (&ClusterNetwork{}).ValidateSubnetCIDRs(chosen); err != nil && vpc.CIDRBlock() != "" {
+		recommendation.MachineCIDR = vpc.CIDRBlock()
+	}
+	return recommendation, nil
+}
@@ -0,0 +1,108 @@
+package ocm
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testVPC builds a VPC with a private and a public subnet in us-east-1a and us-east-1b, a private
+// subnet in us-east-1c and a Red Hat managed subnet in us-east-1d
+func testVPC(t *testing.T, cidr string) *cmv1.CloudVPC {
+	subnet := func(id, zone string, public bool, cidr string) *cmv1.SubnetworkBuilder {
+		return cmv1.NewSubnetwork().SubnetID(id).AvailabilityZone(zone).Public(public).CIDRBlock(cidr)
+	}
+	vpc, err := cmv1.NewCloudVPC().
+		ID("vpc-0123456789abcdef0").
+		CIDRBlock(cidr).
+		AWSSubnets(
+			subnet("subnet-00000000000000c01", "us-east-1c", false, "10.0.4.0/24"),
+			subnet("subnet-00000000000000b02", "us-east-1b", true, "10.0.3.0/24"),
+			subnet("subnet-00000000000000b01", "us-east-1b", false, "10.0.2.0/24"),
+			subnet("subnet-00000000000000a02", "us-east-1a", true, "10.0.1.0/24"),
+			subnet("subnet-00000000000000a01", "us-east-1a", false, "10.0.0.0/24"),
+			subnet("subnet-00000000000000d01", "us-east-1d", false, "10.0.5.0/24").RedHatManaged(true),
+		).
+		Build()
+	assert.NoError(t, err)
+	return vpc
+}
+
+func TestRecommendSubnets(t *testing.T) {
+	vpc := testVPC(t, "10.0.0.0/16")
+
+	tests := []struct {
+		name      string
+		private   bool
+		zoneCount int
+		subnetIDs []string
+		zones     []string
+		wantErr   string
+	}{
+		{
+			name:      "public",
+			subnetIDs: []string{"subnet-00000000000000a01", "subnet-00000000000000b01", "subnet-00000000000000a02", "subnet-00000000000000b02"},
+			zones:     []string{"us-east-1a", "us-east-1b"},
+		},
+		{
+			name:      "public single zone",
+			zoneCount: 1,
+			subnetIDs: []string{"subnet-00000000000000a01", "subnet-00000000000000a02"},
+			zones:     []string{"us-east-1a"},
+		},
+		{
+			name:      "private",
+			private:   true,
+			subnetIDs: []string{"subnet-00000000000000a01", "subnet-00000000000000b01", "subnet-00000000000000c01"},
+			zones:     []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+		},
+		{
+			name:      "public three zones",
+			zoneCount: 3,
+			wantErr:   "2 availability zone(s), 3 requested",
+		},
+		{
+			name:      "invalid zone count",
+			zoneCount: 4,
+			wantErr:   "invalid zone count",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recommendation, err := RecommendSubnets(vpc, tt.private, tt.zoneCount)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.subnetIDs, recommendation.SubnetIDs)
+			assert.Equal(t, tt.zones, recommendation.AvailabilityZones)
+			assert.Empty(t, recommendation.MachineCIDR)
+		})
+	}
+
+	// The input subnets are left in their original order
+	assert.Equal(t, "subnet-00000000000000c01", vpc.AWSSubnets()[0].SubnetID())
+}
+
+func TestRecommendSubnetsMachineCIDR(t *testing.T) {
+	vpc, err := cmv1.NewCloudVPC().
+		ID("vpc-0123456789abcdef0").
+		CIDRBlock("192.168.0.0/16").
+		AWSSubnets(cmv1.NewSubnetwork().SubnetID("subnet-00000000000000a01").AvailabilityZone("us-east-1a").CIDRBlock("192.168.0.0/24")).
+		Build()
+	assert.NoError(t, err)
+
+	recommendation, err := RecommendSubnets(vpc, true, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.0.0/16", recommendation.MachineCIDR)
+
+	_, err = RecommendSubnets(vpc, false, 0)
+	assert.ErrorContains(t, err, "no availability zone with both a private and a public subnet")
+}
+
+func TestRequiredELBRoleTag(t *testing.T) {
+	assert.Equal(t, ELBRoleTag, RequiredELBRoleTag(testSubnet(t, "subnet-0123456789abcdef0", true, "10.0.0.0/24")))
+	assert.Equal(t, InternalELBRoleTag, RequiredELBRoleTag(testSubnet(t, "subnet-0123456789abcdef0", false, "10.0.0.0/24")))
+}
@@ -0,0 +1,237 @@
+// Package validation checks ROSA HCP cluster inputs locally, before they are sent to OCM
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// MaxClusterNameLength is the longest cluster name accepted by ROSA
+	MaxClusterNameLength = 54
+	// MaxOperatorRolePrefixLength is the longest operator role prefix accepted by ROSA
+	MaxOperatorRolePrefixLength = 32
+)
+
+var (
+	clusterNameRE        = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+	awsAccountIDRE       = regexp.MustCompile(`^\d{12}$`)
+	operatorRolePrefixRE = regexp.MustCompile(`^[\w+=,.@-]+$`)
+	subnetIDRE           = regexp.MustCompile(`^subnet-(?:[0-9a-f]{8}|[0-9a-f]{17})$`)
+	regionRE             = regexp.MustCompile(`^[a-z]{2}(?:-gov)?-[a-z]+-\d$`)
+
+	// roleARNRE matches IAM role ARNs, capturing the account ID
+	roleARNRE = regexp.MustCompile(`^arn:aws(?:-cn|-us-gov)?:iam::(\d{12}):role/(?:[\w+=,.@-]+/)*[\w+=,.@-]{1,64}$`)
+	// principalARNRE matches IAM user, role, assumed-role and root ARNs, capturing the account ID
+	principalARNRE = regexp.MustCompile(`^arn:aws(?:-cn|-us-gov)?:(?:iam::(\d{12}):(?:root|(?:user|role)/(?:[\w+=,.@-]+/)*[\w+=,.@-]+)|sts::(\d{12}):assumed-role/[\w+=,.@-]+/[\w+=,.@-]+)$`)
+)
+
+// Issue is a problem with one input of a cluster configuration
+type Issue struct {
+	Field   string
+	Message string
+}
+
+func (i Issue) Error() string {
+	if i.Field == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Field, i.Message)
+}
+
+// Issues is the list of problems found in a cluster configuration
+type Issues []Issue
+
+func (issues Issues) Error() string {
+	if len(issues) == 1 {
+		return "invalid cluster configuration: " + issues[0].Error()
+	}
+	lines := make([]string, 0, len(issues)+1)
+	lines = append(lines, fmt.Sprintf("invalid cluster configuration (%d problems):", len(issues)))
+	for _, issue := range issues {
+		lines = append(lines, "- "+issue.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// add appends an issue for field when err is not nil
+func (issues *Issues) add(field string, err error) {
+	if err != nil {
+		*issues = append(*issues, Issue{Field: field, Message: err.Error()})
+	}
+}
+
+// ClusterConfig holds the identity, role and networking inputs of a ROSA HCP cluster
+type ClusterConfig struct {
+	Name               string
+	Region             string
+	AWSAccountID       string
+	BillingAccountID   string
+	InstallerRoleARN   string
+	SupportRoleARN     string
+	WorkerRoleARN      string
+	OperatorRolePrefix string
+	CreatorARN         string
+	SubnetIDs          []string
+	AvailabilityZones  []string
+	Private            bool
+}
+
+// ValidateClusterConfig checks every input of the configuration and returns all problems found
+func ValidateClusterConfig(config ClusterConfig) Issues {
+	var issues Issues
+	issues.add("cluster_name", ValidateClusterName(config.Name))
+	issues.add("region", ValidateRegion(config.Region))
+	issues.add("aws_account_id", ValidateAWSAccountID(config.AWSAccountID))
+	issues.add("billing_account_id", ValidateAWSAccountID(config.BillingAccountID))
+
+	// Only check that the roles belong to the cluster's account when the account ID is usable
+	accountID := config.AWSAccountID
+	if ValidateAWSAccountID(accountID) != nil {
+		accountID = ""
+	}
+	issues.add("role_arn", ValidateRoleARN(config.InstallerRoleARN, accountID))
+	issues.add("support_role_arn", ValidateRoleARN(config.SupportRoleARN, accountID))
+	issues.add("worker_role_arn", ValidateRoleARN(config.WorkerRoleARN, accountID))
+	issues.add("rosa_creator_arn", ValidatePrincipalARN(config.CreatorARN, accountID))
+	issues.add("operator_role_prefix", ValidateOperatorRolePrefix(config.OperatorRolePrefix))
+
+	for _, subnetID := range config.SubnetIDs {
+		issues.add("subnet_ids", ValidateSubnetID(subnetID))
+	}
+	issues.add("subnet_ids", duplicates(config.SubnetIDs))
+
+	if ValidateRegion(config.Region) == nil {
+		for _, zone := range config.AvailabilityZones {
+			issues.add("availability_zones", ValidateAvailabilityZone(zone, config.Region))
+		}
+	}
+	issues.add("availability_zones", duplicates(config.AvailabilityZones))
+	issues.add("subnet_ids", ValidateSubnetCount(len(config.SubnetIDs), len(config.AvailabilityZones), config.Private))
+
+	return issues
+}
+
+// ValidateClusterName checks the ROSA cluster name rules: lowercase alphanumeric characters or '-',
+// starting with a letter, ending with an alphanumeric character and at most 54 characters long
+func ValidateClusterName(name string) error {
+	if name == "" {
+		return fmt.Errorf("cluster name is required")
+	}
+	if len(name) > MaxClusterNameLength {
+		return fmt.Errorf("cluster name '%s' is %d characters long; the maximum is %d", name, len(name), MaxClusterNameLength)
+	}
+	if !clusterNameRE.MatchString(name) {
+		return fmt.Errorf("cluster name '%s' must consist of lowercase alphanumeric characters or '-', start with a letter and end with an alphanumeric character", name)
+	}
+	return nil
+}
+
+// ValidateAWSAccountID checks that id is a 12-digit AWS account ID
+func ValidateAWSAccountID(id string) error {
+	if !awsAccountIDRE.MatchString(id) {
+		return fmt.Errorf("'%s' is not an AWS account ID: expected 12 digits", id)
+	}
+	return nil
+}
+
+// ValidateRegion checks that region looks like an AWS region code, e.g. us-east-1
+func ValidateRegion(region string) error {
+	if !regionRE.MatchString(region) {
+		return fmt.Errorf("'%s' is not an AWS region code such as us-east-1", region)
+	}
+	return nil
+}
+
+// ValidateRoleARN checks that arn is an IAM role ARN and, when accountID is set, that the role belongs to that account
+func ValidateRoleARN(arn, accountID string) error {
+	match := roleARNRE.FindStringSubmatch(arn)
+	if match == nil {
+		return fmt.Errorf("'%s' is not an IAM role ARN: expected arn:aws:iam::<account-id>:role/<name>", arn)
+	}
+	if accountID != "" && match[1] != accountID {
+		return fmt.Errorf("role '%s' belongs to account %s, not the cluster's AWS account %s", arn, match[1], accountID)
+	}
+	return nil
+}
+
+// ValidatePrincipalARN checks that arn identifies an IAM user, role, assumed role or account root and,
+// when accountID is set, that it belongs to that account
+func ValidatePrincipalARN(arn, accountID string) error {
+	match := principalARNRE.FindStringSubmatch(arn)
+	if match == nil {
+		return fmt.Errorf("'%s' is not an IAM user, role or assumed-role ARN", arn)
+	}
+	principalAccount := match[1] + match[2]
+	if accountID != "" && principalAccount != accountID {
+		return fmt.Errorf("'%s' belongs to account %s, not the cluster's AWS account %s", arn, principalAccount, accountID)
+	}
+	return nil
+}
+
+// ValidateOperatorRolePrefix checks the ROSA operator role prefix rules
+func ValidateOperatorRolePrefix(prefix string) error {
+	if prefix == "" {
+		return fmt.Errorf("operator role prefix is required")
+	}
+	if len(prefix) > MaxOperatorRolePrefixLength {
+		return fmt.Errorf("operator role prefix '%s' is %d characters long; the maximum is %d", prefix, len(prefix), MaxOperatorRolePrefixLength)
+	}
+	if !operatorRolePrefixRE.MatchString(prefix) {
+		return fmt.Errorf("operator role prefix '%s' may only contain alphanumeric characters and + = , . @ - _", prefix)
+	}
+	return nil
+}
+
+// ValidateSubnetID checks that id is an AWS subnet ID, e.g. subnet-0123456789abcdef0
+func ValidateSubnetID(id string) error {
+	if !subnetIDRE.MatchString(id) {
+		return fmt.Errorf("'%s' is not a subnet ID: expected subnet- followed by 8 or 17 hexadecimal characters", id)
+	}
+	return nil
+}
+
+// ValidateAvailabilityZone checks that zone is an availability zone of region, e.g. us-east-1a for us-east-1
+func ValidateAvailabilityZone(zone, region string) error {
+	suffix, ok := strings.CutPrefix(zone, region)
+	if !ok || len(suffix) != 1 || suffix[0] < 'a' || suffix[0] > 'z' {
+		return fmt.Errorf("'%s' is not an availability zone of region %s; expected e.g. %sa", zone, region, region)
+	}
+	return nil
+}
+
+// ValidateSubnetCount checks that there are enough subnets for the availability zones: every zone needs a
+// private subnet, and public clusters also need at least one public subnet for their load balancers
+func ValidateSubnetCount(subnets, zones int, private bool) error {
+	if subnets == 0 {
+		return fmt.Errorf("at least one subnet is required")
+	}
+	if zones == 0 {
+		return nil
+	}
+	if private && subnets < zones {
+		return fmt.Errorf("%d subnets for %d availability zones: private clusters need a private subnet in each zone", subnets, zones)
+	}
+	if !private && subnets < zones+1 {
+		return fmt.Errorf("%d subnets for %d availability zones: public clusters need a private subnet in each zone and at least one public subnet", subnets, zones)
+	}
+	return nil
+}
+
+// duplicates returns an error listing the values that appear more than once
+func duplicates(values []string) error {
+	seen := make(map[string]bool, len(values))
+	var repeated []string
+	for _, value := range values {
+		if seen[value] && !slices.Contains(repeated, value) {
+			repeated = append(repeated, value)
+		}
+		seen[value] = true
+	}
+	if len(repeated) > 0 {
+		return fmt.Errorf("duplicate values: %s", strings.Join(repeated, ", "))
+	}
+	return nil
+}
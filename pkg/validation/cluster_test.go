@@ -0,0 +1,135 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testClusterConfig returns a configuration without problems
+func testClusterConfig() ClusterConfig {
+	return ClusterConfig{
+		Name:               "my-cluster",
+		Region:             "us-east-1",
+		AWSAccountID:       "123456789012",
+		BillingAccountID:   "123456789012",
+		InstallerRoleARN:   "arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Installer-Role",
+		SupportRoleARN:     "arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Support-Role",
+		WorkerRoleARN:      "arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Worker-Role",
+		OperatorRolePrefix: "my-cluster",
+		CreatorARN:         "arn:aws:iam::123456789012:user/admin",
+		SubnetIDs:          []string{"subnet-0123456789abcdef0", "subnet-0123456789abcdef1"},
+		AvailabilityZones:  []string{"us-east-1a"},
+	}
+}
+
+func TestValidateClusterConfig(t *testing.T) {
+	assert.Empty(t, ValidateClusterConfig(testClusterConfig()))
+
+	config := testClusterConfig()
+	config.Name = "My_Cluster"
+	config.WorkerRoleARN = "arn:aws:iam::210987654321:role/Worker-Role"
+	config.AvailabilityZones = []string{"us-west-2a"}
+	issues := ValidateClusterConfig(config)
+	assert.Len(t, issues, 3)
+	assert.Equal(t, "cluster_name", issues[0].Field)
+	assert.Equal(t, "worker_role_arn", issues[1].Field)
+	assert.Equal(t, "availability_zones", issues[2].Field)
+	assert.Contains(t, issues.Error(), "3 problems")
+
+	// Roles are not compared with an invalid account ID
+	config = testClusterConfig()
+	config.AWSAccountID = "1234"
+	issues = ValidateClusterConfig(config)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "aws_account_id", issues[0].Field)
+	assert.True(t, strings.HasPrefix(issues.Error(), "invalid cluster configuration: aws_account_id:"))
+}
+
+func TestValidateClusterName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "my-cluster"},
+		{name: "c"},
+		{name: strings.Repeat("a", MaxClusterNameLength)},
+		{name: "", wantErr: true},
+		{name: strings.Repeat("a", MaxClusterNameLength+1), wantErr: true},
+		{name: "My-Cluster", wantErr: true},
+		{name: "1cluster", wantErr: true},
+		{name: "cluster-", wantErr: true},
+		{name: "my_cluster", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateClusterName(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRoleARN(t *testing.T) {
+	assert.NoError(t, ValidateRoleARN("arn:aws:iam::123456789012:role/Installer-Role", "123456789012"))
+	assert.NoError(t, ValidateRoleARN("arn:aws:iam::123456789012:role/path/to/Installer-Role", "123456789012"))
+	assert.NoError(t, ValidateRoleARN("arn:aws-us-gov:iam::123456789012:role/Installer-Role", ""))
+	assert.Error(t, ValidateRoleARN("arn:aws:iam::123456789012:role/Installer-Role", "210987654321"))
+	assert.Error(t, ValidateRoleARN("arn:aws:iam::123456789012:user/admin", ""))
+	assert.Error(t, ValidateRoleARN("Installer-Role", ""))
+	assert.Error(t, ValidateRoleARN("arn:aws:iam::12345:role/Installer-Role", ""))
+}
+
+func TestValidatePrincipalARN(t *testing.T) {
+	assert.NoError(t, ValidatePrincipalARN("arn:aws:iam::123456789012:user/admin", "123456789012"))
+	assert.NoError(t, ValidatePrincipalARN("arn:aws:iam::123456789012:root", "123456789012"))
+	assert.NoError(t, ValidatePrincipalARN("arn:aws:iam::123456789012:role/admin", ""))
+	assert.NoError(t, ValidatePrincipalARN("arn:aws:sts::123456789012:assumed-role/admin/session", "123456789012"))
+	assert.Error(t, ValidatePrincipalARN("arn:aws:sts::123456789012:assumed-role/admin/session", "210987654321"))
+	assert.Error(t, ValidatePrincipalARN("arn:aws:s3:::bucket", ""))
+}
+
+func TestValidateOperatorRolePrefix(t *testing.T) {
+	assert.NoError(t, ValidateOperatorRolePrefix("my-cluster"))
+	assert.NoError(t, ValidateOperatorRolePrefix("team.prod_1"))
+	assert.Error(t, ValidateOperatorRolePrefix(""))
+	assert.Error(t, ValidateOperatorRolePrefix(strings.Repeat("p", MaxOperatorRolePrefixLength+1)))
+	assert.Error(t, ValidateOperatorRolePrefix("my/prefix"))
+}
+
+func TestValidateSubnetID(t *testing.T) {
+	assert.NoError(t, ValidateSubnetID("subnet-0123456789abcdef0"))
+	assert.NoError(t, ValidateSubnetID("subnet-01234567"))
+	assert.Error(t, ValidateSubnetID("subnet-0123456789ABCDEF0"))
+	assert.Error(t, ValidateSubnetID("subnet-0123"))
+	assert.Error(t, ValidateSubnetID("vpc-0123456789abcdef0"))
+}
+
+func TestValidateAvailabilityZone(t *testing.T) {
+	assert.NoError(t, ValidateAvailabilityZone("us-east-1a", "us-east-1"))
+	assert.Error(t, ValidateAvailabilityZone("us-west-2a", "us-east-1"))
+	assert.Error(t, ValidateAvailabilityZone("us-east-1", "us-east-1"))
+	assert.Error(t, ValidateAvailabilityZone("us-east-1-bos-1a", "us-east-1"))
+	assert.Error(t, ValidateAvailabilityZone("us-east-12", "us-east-1"))
+}
+
+func TestValidateSubnetCount(t *testing.T) {
+	assert.NoError(t, ValidateSubnetCount(2, 1, false))
+	assert.NoError(t, ValidateSubnetCount(6, 3, false))
+	assert.NoError(t, ValidateSubnetCount(3, 3, true))
+	assert.Error(t, ValidateSubnetCount(3, 3, false))
+	assert.Error(t, ValidateSubnetCount(2, 3, true))
+	assert.Error(t, ValidateSubnetCount(0, 0, true))
+}
+
+func TestValidateClusterConfigDuplicates(t *testing.T) {
+	config := testClusterConfig()
+	config.SubnetIDs = []string{"subnet-0123456789abcdef0", "subnet-0123456789abcdef0", "subnet-0123456789abcdef1"}
+	issues := ValidateClusterConfig(config)
+	assert.Len(t, issues, 1)
+	assert.Contains(t, issues[0].Message, "subnet-0123456789abcdef0")
+}